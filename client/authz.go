@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// NewMsgExec wraps msgs in a MsgExec, to be signed by grantee and executed with the
+// authorizations granted to it by the signers of msgs.
+func (cc *ChainClient) NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) (*authz.MsgExec, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no messages to execute")
+	}
+	anys := make([]*codectypes.Any, len(msgs))
+	for i, msg := range msgs {
+		var err error
+		if anys[i], err = codectypes.NewAnyWithValue(msg); err != nil {
+			return nil, err
+		}
+	}
+	granteeAddr, err := cc.EncodeBech32AccAddr(grantee)
+	if err != nil {
+		return nil, err
+	}
+	return &authz.MsgExec{Grantee: granteeAddr, Msgs: anys}, nil
+}
+
+// SendMsgsAsGrantee sends msgs on behalf of granter, with the authorizations granter
+// granted to the configured key. The msgs are built as if granter sent them itself,
+// SendMsgsAsGrantee wraps them in a MsgExec signed by the key.
+func (cc *ChainClient) SendMsgsAsGrantee(ctx context.Context, granter sdk.AccAddress, msgs []sdk.Msg, memo string, opts ...TxOption) (*sdk.TxResponse, error) {
+	if err := cc.checkSigner(granter, msgs); err != nil {
+		return nil, err
+	}
+
+	grantee, err := cc.GetKeyAddress()
+	if err != nil {
+		return nil, err
+	}
+	exec, err := cc.NewMsgExec(grantee, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return cc.SendMsg(ctx, exec, memo, opts...)
+}
+
+// checkSigner returns an error unless signer is the only signer of each of msgs.
+func (cc *ChainClient) checkSigner(signer sdk.AccAddress, msgs []sdk.Msg) error {
+	// GetSigners decodes the bech32 addresses of msgs with the global prefixes.
+	done := cc.SetSDKContext()
+	defer done()
+	for _, msg := range msgs {
+		signers := msg.GetSigners()
+		if len(signers) != 1 || !signers[0].Equals(signer) {
+			return fmt.Errorf("%s must be signed by %s alone", sdk.MsgTypeURL(msg), cc.MustEncodeAccAddr(signer))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNewMsgExec(t *testing.T) {
+	homepath := t.TempDir()
+	cl, err := client.NewChainClient(
+		zaptest.NewLogger(t),
+		client.GetCosmosHubConfig(homepath, true),
+		homepath, nil, nil,
+	)
+	require.NoError(t, err)
+
+	granter, err := cl.AddKey("granter", sdk.CoinType)
+	require.NoError(t, err)
+	grantee, err := cl.AddKey("grantee", sdk.CoinType)
+	require.NoError(t, err)
+	cl.Config.Key = "grantee"
+
+	send := &banktypes.MsgSend{
+		FromAddress: granter.Address,
+		ToAddress:   grantee.Address,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 100)),
+	}
+	granteeAddr, err := cl.DecodeBech32AccAddr(grantee.Address)
+	require.NoError(t, err)
+	exec, err := cl.NewMsgExec(granteeAddr, []sdk.Msg{send})
+	require.NoError(t, err)
+	require.Equal(t, grantee.Address, exec.Grantee)
+	require.Len(t, exec.Msgs, 1)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", exec.Msgs[0].TypeUrl)
+
+	_, err = cl.NewMsgExec(granteeAddr, nil)
+	require.Error(t, err)
+
+	// The msgs have to be signed by the granter, checked before anything is sent.
+	_, err = cl.SendMsgsAsGrantee(context.Background(), granteeAddr, []sdk.Msg{send}, "")
+	require.ErrorContains(t, err, "must be signed by")
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// blockTimeCacheSize is the number of block times kept by blockTimeCache.
+// Txs are usually looked up shortly after inclusion, so recent heights suffice.
+const blockTimeCacheSize = 256
+
+// blockTimeCache remembers block times by height, so that many txs included in
+// the same block only cost a single header query.
+type blockTimeCache struct {
+	mu      sync.Mutex
+	times   map[int64]time.Time
+	heights []int64 // in insertion order, oldest first
+}
+
+func (c *blockTimeCache) get(height int64) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t, ok := c.times[height]
+	return t, ok
+}
+
+func (c *blockTimeCache) add(height int64, t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.times == nil {
+		c.times = make(map[int64]time.Time)
+	}
+	if _, ok := c.times[height]; ok {
+		return
+	}
+	if len(c.heights) >= blockTimeCacheSize {
+		delete(c.times, c.heights[0])
+		c.heights = c.heights[1:]
+	}
+	c.times[height] = t
+	c.heights = append(c.heights, height)
+}
+
+// BlockTime returns the time of the block at the given height.
+func (cc *ChainClient) BlockTime(ctx context.Context, height int64) (time.Time, error) {
+	if t, ok := cc.blockTimes.get(height); ok {
+		return t, nil
+	}
+	res, err := cc.RPCClient.Header(ctx, &height)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cc.blockTimes.add(height, res.Header.Time)
+	return res.Header.Time, nil
+}
+
+// HeightAtTime returns the height of the last block at or before t, found by a binary search
+// over the times of the blocks the node has. The latest height is returned if t is after the
+// latest block.
+func (cc *ChainClient) HeightAtTime(ctx context.Context, t time.Time) (int64, error) {
+	stat, err := cc.RPCClient.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	lo, hi := stat.SyncInfo.EarliestBlockHeight, stat.SyncInfo.LatestBlockHeight
+	if !stat.SyncInfo.LatestBlockTime.After(t) {
+		return hi, nil
+	}
+	if lo < 1 {
+		lo = 1
+	}
+	loTime, err := cc.BlockTime(ctx, lo)
+	if err != nil {
+		return 0, err
+	}
+	if loTime.After(t) {
+		return 0, fmt.Errorf("%s is before the earliest block %d of the node at %s", t.Format(time.RFC3339), lo, loTime.Format(time.RFC3339))
+	}
+
+	// The block at lo is at or before t, the block at hi after it
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		midTime, err := cc.BlockTime(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if midTime.After(t) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return lo, nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+// blockTimesClient serves the blocks from earliest to latest, a block every 6 seconds from genesis.
+type blockTimesClient struct {
+	rpcclient.Client
+	genesis          time.Time
+	earliest, latest int64
+}
+
+func (c blockTimesClient) blockTime(height int64) time.Time {
+	return c.genesis.Add(time.Duration(height-1) * 6 * time.Second)
+}
+
+func (c blockTimesClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	res := &coretypes.ResultStatus{}
+	res.SyncInfo.EarliestBlockHeight = c.earliest
+	res.SyncInfo.LatestBlockHeight = c.latest
+	res.SyncInfo.LatestBlockTime = c.blockTime(c.latest)
+	return res, nil
+}
+
+func (c blockTimesClient) Header(_ context.Context, height *int64) (*coretypes.ResultHeader, error) {
+	return &coretypes.ResultHeader{Header: &tmtypes.Header{Height: *height, Time: c.blockTime(*height)}}, nil
+}
+
+func TestHeightAtTime(t *testing.T) {
+	genesis := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	rpc := blockTimesClient{genesis: genesis, earliest: 1000, latest: 100000}
+	cc := &ChainClient{RPCClient: rpc}
+	ctx := context.Background()
+
+	// The last block at or before the time
+	height, err := cc.HeightAtTime(ctx, rpc.blockTime(54321))
+	require.NoError(t, err)
+	require.Equal(t, int64(54321), height)
+	height, err = cc.HeightAtTime(ctx, rpc.blockTime(54321).Add(5*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, int64(54321), height)
+
+	height, err = cc.HeightAtTime(ctx, rpc.blockTime(1000))
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), height)
+
+	height, err = cc.HeightAtTime(ctx, rpc.blockTime(200000))
+	require.NoError(t, err)
+	require.Equal(t, int64(100000), height)
+
+	_, err = cc.HeightAtTime(ctx, genesis)
+	require.ErrorContains(t, err, "before the earliest block 1000")
+}
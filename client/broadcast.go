@@ -1,9 +1,11 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -11,56 +13,218 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	tmtypes "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
 )
 
 const (
 	defaultBroadcastWaitTimeout = 10 * time.Minute
+	defaultTxPollInterval       = 100 * time.Millisecond
 	errUnknown                  = "unknown"
+
+	// txSubscriber is the subscriber name used for tx inclusion events.
+	txSubscriber = "lens"
 )
 
-func (cc *ChainClient) BroadcastTx(ctx context.Context, tx []byte) (*sdk.TxResponse, error) {
-	var (
-		blockTimeout time.Duration = defaultBroadcastWaitTimeout
-		err          error
-	)
+const (
+	// BroadcastModeAsync returns right after the tx was handed to the node,
+	// without waiting for CheckTx.
+	BroadcastModeAsync = "async"
+	// BroadcastModeSync returns once the tx has passed CheckTx.
+	BroadcastModeSync = "sync"
+	// BroadcastModeCommit returns once the tx has been included in a block.
+	// This is the default.
+	BroadcastModeCommit = "commit"
+)
 
-	if cc.Config.BlockTimeout != "" {
-		blockTimeout, err = time.ParseDuration(cc.Config.BlockTimeout)
+// BroadcastTx broadcasts the tx using the configured broadcast mode unless
+// overridden by opts.
+func (cc *ChainClient) BroadcastTx(ctx context.Context, tx []byte, opts ...TxOption) (*sdk.TxResponse, error) {
+	o := cc.txOptionsFromConfig(opts...)
+	if err := validateBroadcastMode(o.broadcastMode); err != nil {
+		return nil, err
+	}
+
+	switch o.broadcastMode {
+	case BroadcastModeAsync:
+		res, err := cc.RPCClient.BroadcastTxAsync(ctx, tx)
 		if err != nil {
-			// Did you call Validate() method on ChainClientConfig struct
-			// before coming here?
 			return nil, err
 		}
+		return newBroadcastTxResponse(res), nil
+	case BroadcastModeSync:
+		res, err := broadcastTxSync(ctx, cc.RPCClient, tx)
+		if res == nil {
+			return nil, err
+		}
+		return newBroadcastTxResponse(res), err
+	}
+
+	wait, err := cc.txWaitConfig(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return broadcastTx(
 		ctx,
 		cc.RPCClient,
-		cc.Codec.TxConfig.TxDecoder(),
+		cc.mkTxResult,
 		tx,
-		blockTimeout,
+		wait,
+	)
+}
+
+// validateBroadcastMode returns an error if mode is not one of the BroadcastMode constants.
+// An empty mode is valid and defaults to BroadcastModeCommit.
+func validateBroadcastMode(mode string) error {
+	switch mode {
+	case "", BroadcastModeAsync, BroadcastModeSync, BroadcastModeCommit:
+		return nil
+	default:
+		return fmt.Errorf("unknown broadcast mode %q, expected one of: %s, %s, %s", mode, BroadcastModeAsync, BroadcastModeSync, BroadcastModeCommit)
+	}
+}
+
+// blockTimeout returns the configured time to wait for a tx to be included in a block.
+func (cc *ChainClient) blockTimeout() (time.Duration, error) {
+	if cc.Config.BlockTimeout == "" {
+		return defaultBroadcastWaitTimeout, nil
+	}
+	// Did you call Validate() method on ChainClientConfig struct
+	// before coming here?
+	return time.ParseDuration(cc.Config.BlockTimeout)
+}
+
+// txWaitConfig returns how to wait for a tx to be included in a block.
+func (cc *ChainClient) txWaitConfig(ctx context.Context) (txWaitConfig, error) {
+	var (
+		wait txWaitConfig
+		err  error
 	)
+	if wait.timeout, err = cc.blockTimeout(); err != nil {
+		return txWaitConfig{}, err
+	}
+	if cc.Config.TxPollInterval != "" {
+		if wait.pollInterval, err = time.ParseDuration(cc.Config.TxPollInterval); err != nil {
+			return txWaitConfig{}, err
+		}
+	}
+	if cc.Config.TxPollMaxInterval != "" {
+		if wait.maxPollInterval, err = time.ParseDuration(cc.Config.TxPollMaxInterval); err != nil {
+			return txWaitConfig{}, err
+		}
+	}
+	if wait.txIndexEnabled, err = cc.txIndexEnabled(ctx); err != nil {
+		return txWaitConfig{}, err
+	}
+	if !wait.txIndexEnabled {
+		// The tx can't be found by hash if it is included before the subscription to its
+		// event, the blocks after the latest one are searched instead
+		status, err := cc.RPCClient.Status(ctx)
+		if err != nil {
+			return txWaitConfig{}, err
+		}
+		wait.fromHeight = status.SyncInfo.LatestBlockHeight
+	}
+	return wait, nil
+}
+
+// txIndexStatus caches whether the node indexes txs, so that it is only queried once.
+type txIndexStatus struct {
+	mu      sync.Mutex
+	checked bool
+	enabled bool
+}
+
+// txIndexEnabled reports whether the node indexes txs, i.e. whether txs can be
+// found by hash.
+func (cc *ChainClient) txIndexEnabled(ctx context.Context) (bool, error) {
+	cc.txIndex.mu.Lock()
+	defer cc.txIndex.mu.Unlock()
+
+	if !cc.txIndex.checked {
+		status, err := cc.RPCClient.Status(ctx)
+		if err != nil {
+			return false, err
+		}
+		cc.txIndex.enabled = status.NodeInfo.Other.TxIndex != "off"
+		cc.txIndex.checked = true
+	}
+	return cc.txIndex.enabled, nil
 }
 
 type rpcTxBroadcaster interface {
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 	BroadcastTxSync(context.Context, tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)
+	BroadcastTxAsync(context.Context, tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)
+}
+
+// rpcTxSubscriber is implemented by RPC clients that can push tx events over
+// a websocket, such as rpchttp.HTTP. The websocket is only connected once the
+// client has been started.
+type rpcTxSubscriber interface {
+	Start() error
+	IsRunning() bool
+	Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error)
+	Unsubscribe(ctx context.Context, subscriber, query string) error
+}
+
+// rpcBlockFetcher is implemented by RPC clients that can return blocks and their results,
+// such as rpchttp.HTTP.
+type rpcBlockFetcher interface {
+	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+}
+
+// txResultFunc builds the response for a tx that has been included in a block.
+type txResultFunc func(ctx context.Context, resTx *ctypes.ResultTx) (*sdk.TxResponse, error)
 
-	// TODO: implement commit and async as well
-	// BroadcastTxCommit(context.Context, tmtypes.Tx) (*ctypes.ResultBroadcastTxCommit, error)
-	// BroadcastTxAsync(context.Context, tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)
+// txWaitConfig configures how broadcastTx waits for a TX to be included in a block.
+type txWaitConfig struct {
+	// timeout is how long to wait in total.
+	timeout time.Duration
+	// pollInterval is the initial interval between Tx queries when polling.
+	// It doubles after every miss up to maxPollInterval.
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	// txIndexEnabled is false if the node can't find txs by hash, in which
+	// case only websocket events can tell us about inclusion.
+	txIndexEnabled bool
+	// fromHeight is the latest height before the broadcast when txIndexEnabled is false,
+	// the blocks after it are searched for a tx included before the subscription.
+	fromHeight int64
 }
 
 // broadcastTx broadcasts a TX and then waits for the TX to be included in the block.
-// The waiting will either be canceled after the waitTimeout has run out or the context
+// The waiting will either be canceled after the wait timeout has run out or the context
 // exited.
 func broadcastTx(
 	ctx context.Context,
 	broadcaster rpcTxBroadcaster,
-	txDecoder sdk.TxDecoder,
+	mkResult txResultFunc,
 	tx []byte,
-	waitTimeout time.Duration,
+	wait txWaitConfig,
 ) (*sdk.TxResponse, error) {
+	syncRes, err := broadcastTxSync(ctx, broadcaster, tx)
+	if err != nil {
+		if syncRes == nil {
+			// There are some cases where BroadcastTxSync will return an error but the associated
+			// ResultBroadcastTx will be nil.
+			return nil, err
+		}
+		return newBroadcastTxResponse(syncRes), err
+	}
+
+	return waitForTx(ctx, broadcaster, mkResult, syncRes.Hash, wait)
+}
+
+// broadcastTxSync broadcasts a TX and returns once it has passed CheckTx. If the TX
+// was rejected, the result is returned alongside the error so the caller can inspect
+// the log.
+func broadcastTxSync(
+	ctx context.Context,
+	broadcaster rpcTxBroadcaster,
+	tx []byte,
+) (*ctypes.ResultBroadcastTx, error) {
 	// broadcast tx sync waits for check tx to pass
 	// NOTE: this can return w/ a timeout
 	// need to investigate if this will leave the tx
@@ -69,40 +233,196 @@ func broadcastTx(
 
 	syncRes, err := broadcaster.BroadcastTxSync(ctx, tx)
 	if err != nil {
-		if syncRes == nil {
-			// There are some cases where BroadcastTxSync will return an error but the associated
-			// ResultBroadcastTx will be nil.
-			return nil, err
-		}
-		return &sdk.TxResponse{
-			Code:      syncRes.Code,
-			Codespace: syncRes.Codespace,
-			TxHash:    syncRes.Hash.String(),
-		}, err
+		return syncRes, err
 	}
 
 	// ABCIError will return an error other than "unknown" if syncRes.Code is a registered error in syncRes.Codespace
 	// This catches all of the sdk errors https://github.com/cosmos/cosmos-sdk/blob/f10f5e5974d2ecbf9efc05bc0bfe1c99fdeed4b6/types/errors/errors.go
 	err = errors.Unwrap(sdkerrors.ABCIError(syncRes.Codespace, syncRes.Code, "error broadcasting transaction"))
 	if err.Error() != errUnknown {
+		return syncRes, err
+	}
+
+	return syncRes, nil
+}
+
+// waitForTx waits for the TX with the given hash to be included in a block. It
+// subscribes to the TX event over the websocket if the broadcaster supports it and
+// otherwise falls back to polling. Without a tx index, the blocks after wait.fromHeight
+// are searched for a TX included before the subscription, and polled if the subscription
+// fails. The waiting will either be canceled after the wait timeout has run out or the
+// context exited.
+func waitForTx(
+	ctx context.Context,
+	broadcaster rpcTxBroadcaster,
+	mkResult txResultFunc,
+	hash []byte,
+	wait txWaitConfig,
+) (*sdk.TxResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	exitAfter := time.After(wait.timeout)
+
+	// poll finds the TX by hash, or in the blocks since the broadcast without a tx index
+	poll := func(reason string) (*sdk.TxResponse, error) {
+		if wait.txIndexEnabled {
+			return pollTx(ctx, broadcaster, mkResult, hash, wait, exitAfter)
+		}
+		fetcher, ok := broadcaster.(rpcBlockFetcher)
+		if !ok {
+			return nil, fmt.Errorf("%s; %w", reason, ErrTxIndexingDisabled)
+		}
+		return pollBlocks(ctx, fetcher, mkResult, hash, wait, exitAfter)
+	}
+
+	events, err := subscribeTx(ctx, broadcaster, hash)
+	if err != nil {
+		return poll(fmt.Sprintf("cannot subscribe to tx events: %v", err))
+	}
+
+	// The TX may have been included before the subscription was made.
+	if wait.txIndexEnabled {
+		if resTx, err := broadcaster.Tx(ctx, hash, false); err == nil {
+			return mkResult(ctx, resTx)
+		}
+	} else if fetcher, ok := broadcaster.(rpcBlockFetcher); ok && wait.fromHeight > 0 {
+		resTx, _, err := findTxInBlocks(ctx, fetcher, hash, wait.fromHeight)
+		if err != nil {
+			return nil, err
+		}
+		if resTx != nil {
+			return mkResult(ctx, resTx)
+		}
+	}
+
+	select {
+	case <-exitAfter:
+		return nil, fmt.Errorf("timed out after: %d; %w", wait.timeout, ErrTimeoutAfterWaitingForTxBroadcast)
+	case ev, ok := <-events:
+		if !ok {
+			// The subscription was dropped, we can still try to find the TX.
+			return poll("tx subscription closed")
+		}
+		data, ok := ev.Data.(tmtypes.EventDataTx)
+		if !ok {
+			return nil, fmt.Errorf("unexpected tx event data: %T", ev.Data)
+		}
+		return mkResult(ctx, &ctypes.ResultTx{
+			Hash:     hash,
+			Height:   data.Height,
+			Index:    data.Index,
+			TxResult: data.Result,
+			Tx:       data.Tx,
+		})
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// subscribeTx subscribes to the inclusion event of the TX with the given hash. The
+// subscription is removed once ctx is done.
+func subscribeTx(ctx context.Context, broadcaster rpcTxBroadcaster, hash []byte) (<-chan ctypes.ResultEvent, error) {
+	sub, ok := broadcaster.(rpcTxSubscriber)
+	if !ok {
+		return nil, fmt.Errorf("rpc client does not support subscriptions: %T", broadcaster)
+	}
+	if !sub.IsRunning() {
+		// Start fails if another goroutine started the client in the meantime
+		if err := sub.Start(); err != nil && !sub.IsRunning() {
+			return nil, err
+		}
+	}
+
+	query := fmt.Sprintf("%s='%s' AND %s='%X'", tmtypes.EventTypeKey, tmtypes.EventTx, tmtypes.TxHashKey, hash)
+	events, err := sub.Subscribe(ctx, txSubscriber, query)
+	if err != nil {
 		return nil, err
 	}
+	go func() {
+		<-ctx.Done()
+		_ = sub.Unsubscribe(context.Background(), txSubscriber, query)
+	}()
+	return events, nil
+}
+
+// findTxInBlocks searches the blocks after fromHeight up to the latest one for the TX with
+// the given hash. It returns nil if the TX isn't in any of them, and the latest height it
+// searched. If fromHeight is unknown only the latest block is searched.
+func findTxInBlocks(ctx context.Context, fetcher rpcBlockFetcher, hash []byte, fromHeight int64) (*ctypes.ResultTx, int64, error) {
+	latest, err := fetcher.Block(ctx, nil)
+	if err != nil {
+		return nil, fromHeight, err
+	}
+	if fromHeight <= 0 {
+		fromHeight = latest.Block.Height - 1
+	}
+	for height := fromHeight + 1; height <= latest.Block.Height; height++ {
+		block := latest
+		if height != latest.Block.Height {
+			h := height
+			if block, err = fetcher.Block(ctx, &h); err != nil {
+				return nil, height - 1, err
+			}
+		}
+		for i, tx := range block.Block.Txs {
+			if !bytes.Equal(tx.Hash(), hash) {
+				continue
+			}
+			h := height
+			results, err := fetcher.BlockResults(ctx, &h)
+			if err != nil {
+				return nil, height - 1, err
+			}
+			if i >= len(results.TxsResults) {
+				return nil, height - 1, fmt.Errorf("no result for tx %d of block %d", i, height)
+			}
+			return &ctypes.ResultTx{
+				Hash:     hash,
+				Height:   height,
+				Index:    uint32(i),
+				TxResult: *results.TxsResults[i],
+				Tx:       tx,
+			}, height, nil
+		}
+	}
+	return nil, latest.Block.Height, nil
+}
 
-	// TODO: maybe we need to check if the node has tx indexing enabled?
-	// if not, we need to find a new way to block until inclusion in a block
+// pollIntervals returns the first and the longest interval between polls of wait.
+func pollIntervals(wait txWaitConfig) (time.Duration, time.Duration) {
+	interval := wait.pollInterval
+	if interval <= 0 {
+		interval = defaultTxPollInterval
+	}
+	maxInterval := wait.maxPollInterval
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	return interval, maxInterval
+}
 
-	// wait for tx to be included in a block
-	exitAfter := time.After(waitTimeout)
+// pollTx queries for the TX with the given hash until it is found, backing off
+// between attempts.
+func pollTx(
+	ctx context.Context,
+	broadcaster rpcTxBroadcaster,
+	mkResult txResultFunc,
+	hash []byte,
+	wait txWaitConfig,
+	exitAfter <-chan time.Time,
+) (*sdk.TxResponse, error) {
+	interval, maxInterval := pollIntervals(wait)
 	for {
 		select {
 		case <-exitAfter:
-			return nil, fmt.Errorf("timed out after: %d; %w", waitTimeout, ErrTimeoutAfterWaitingForTxBroadcast)
-		// TODO: this is potentially less than optimal and may
-		// be better as something configurable
-		case <-time.After(time.Millisecond * 100):
-			resTx, err := broadcaster.Tx(ctx, syncRes.Hash, false)
+			return nil, fmt.Errorf("timed out after: %d; %w", wait.timeout, ErrTimeoutAfterWaitingForTxBroadcast)
+		case <-time.After(interval):
+			resTx, err := broadcaster.Tx(ctx, hash, false)
 			if err == nil {
-				return mkTxResult(txDecoder, resTx)
+				return mkResult(ctx, resTx)
+			}
+			if interval *= 2; interval > maxInterval {
+				interval = maxInterval
 			}
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -110,7 +430,68 @@ func broadcastTx(
 	}
 }
 
-func mkTxResult(txDecoder sdk.TxDecoder, resTx *ctypes.ResultTx) (*sdk.TxResponse, error) {
+// pollBlocks searches the new blocks since wait.fromHeight for the TX with the given hash
+// until it is found, backing off between searches. It waits for TXs on nodes that don't
+// index them.
+func pollBlocks(
+	ctx context.Context,
+	fetcher rpcBlockFetcher,
+	mkResult txResultFunc,
+	hash []byte,
+	wait txWaitConfig,
+	exitAfter <-chan time.Time,
+) (*sdk.TxResponse, error) {
+	interval, maxInterval := pollIntervals(wait)
+	searched := wait.fromHeight
+	for {
+		// Search right away, the TX may be included already
+		resTx, height, err := findTxInBlocks(ctx, fetcher, hash, searched)
+		if err == nil && resTx != nil {
+			return mkResult(ctx, resTx)
+		}
+		searched = height
+
+		select {
+		case <-exitAfter:
+			return nil, fmt.Errorf("timed out after: %d; %w", wait.timeout, ErrTimeoutAfterWaitingForTxBroadcast)
+		case <-time.After(interval):
+			if interval *= 2; interval > maxInterval {
+				interval = maxInterval
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// newBroadcastTxResponse returns the response of a tx that has been broadcast
+// but not yet included in a block.
+func newBroadcastTxResponse(res *ctypes.ResultBroadcastTx) *sdk.TxResponse {
+	return &sdk.TxResponse{
+		Code:      res.Code,
+		Codespace: res.Codespace,
+		TxHash:    res.Hash.String(),
+		RawLog:    res.Log,
+	}
+}
+
+// mkTxResult builds the response for a tx that has been included in a block,
+// timestamped with the time of that block. The timestamp is left empty when the
+// block time can't be queried, the tx is included either way.
+func (cc *ChainClient) mkTxResult(ctx context.Context, resTx *ctypes.ResultTx) (*sdk.TxResponse, error) {
+	blockTime, err := cc.BlockTime(ctx, resTx.Height)
+	if err != nil {
+		cc.log.Warn(
+			"Failed to query block time of tx, leaving its timestamp empty",
+			zap.String("hash", resTx.Hash.String()),
+			zap.Int64("height", resTx.Height),
+			zap.Error(err),
+		)
+	}
+	return mkTxResult(cc.Codec.TxConfig.TxDecoder(), resTx, blockTime)
+}
+
+func mkTxResult(txDecoder sdk.TxDecoder, resTx *ctypes.ResultTx, blockTime time.Time) (*sdk.TxResponse, error) {
 	txb, err := txDecoder(resTx.Tx)
 	if err != nil {
 		return nil, err
@@ -120,11 +501,13 @@ func mkTxResult(txDecoder sdk.TxDecoder, resTx *ctypes.ResultTx) (*sdk.TxRespons
 		return nil, fmt.Errorf("expecting a type implementing intoAny, got: %T", txb)
 	}
 	any := p.AsAny()
-	// TODO: maybe don't make up the time here?
-	// we can fetch the block for the block time buts thats
-	// more round trips
-	// TODO: logs get rendered as base64 encoded, need to fix this somehow
-	return sdk.NewResponseResultTx(resTx, any, time.Now().Format(time.RFC3339)), nil
+	var timestamp string
+	if !blockTime.IsZero() {
+		timestamp = blockTime.Format(time.RFC3339)
+	}
+	res := sdk.NewResponseResultTx(resTx, any, timestamp)
+	res.Events = DecodeEvents(res.Events)
+	return res, nil
 }
 
 // Deprecated: this interface is used only internally for scenario we are
@@ -9,6 +9,10 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	tmtypes "github.com/cometbft/cometbft/types"
 )
@@ -41,8 +45,9 @@ func (m myFakeTx) ValidateBasic() error   { return nil }
 func (m myFakeTx) AsAny() *codectypes.Any { return &codectypes.Any{} }
 
 type fakeBroadcaster struct {
-	tx            func(context.Context, []byte, bool) (*ctypes.ResultTx, error)
-	broadcastSync func(context.Context, tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)
+	tx             func(context.Context, []byte, bool) (*ctypes.ResultTx, error)
+	broadcastSync  func(context.Context, tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)
+	broadcastAsync func(context.Context, tmtypes.Tx) (*ctypes.ResultBroadcastTx, error)
 }
 
 func (f fakeBroadcaster) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
@@ -59,6 +64,20 @@ func (f fakeBroadcaster) BroadcastTxSync(ctx context.Context, tx tmtypes.Tx) (*c
 	return f.broadcastSync(ctx, tx)
 }
 
+func (f fakeBroadcaster) BroadcastTxAsync(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if f.broadcastAsync == nil {
+		return nil, nil
+	}
+	return f.broadcastAsync(ctx, tx)
+}
+
+// decodeTxResult builds tx results without looking up the block time.
+func decodeTxResult(txDecoder sdk.TxDecoder) txResultFunc {
+	return func(_ context.Context, resTx *ctypes.ResultTx) (*sdk.TxResponse, error) {
+		return mkTxResult(txDecoder, resTx, time.Time{})
+	}
+}
+
 func TestBroadcast(t *testing.T) {
 	ctx := context.Background()
 
@@ -130,9 +149,9 @@ func TestBroadcast(t *testing.T) {
 			gotRes, gotErr := broadcastTx(
 				ctx,
 				tt.broadcaster,
-				tt.txDecoder,
+				decodeTxResult(tt.txDecoder),
 				tt.txBytes,
-				duration,
+				txWaitConfig{timeout: duration, txIndexEnabled: true},
 			)
 			if gotRes != nil {
 				// Ignoring timestamp for tests
@@ -144,3 +163,254 @@ func TestBroadcast(t *testing.T) {
 	}
 
 }
+
+// fakeSubscriber is a fakeBroadcaster that also delivers tx events.
+type fakeSubscriber struct {
+	fakeBroadcaster
+	events chan ctypes.ResultEvent
+	query  string
+}
+
+func (f *fakeSubscriber) Start() error    { return nil }
+func (f *fakeSubscriber) IsRunning() bool { return true }
+func (f *fakeSubscriber) Subscribe(_ context.Context, _, query string, _ ...int) (<-chan ctypes.ResultEvent, error) {
+	f.query = query
+	return f.events, nil
+}
+func (f *fakeSubscriber) Unsubscribe(context.Context, string, string) error { return nil }
+
+// fakeChain is a fakeSubscriber that also returns the blocks of a chain, the last block is
+// the latest one. A pending block is added to the chain each time the latest block is queried.
+type fakeChain struct {
+	*fakeSubscriber
+	blocks  []*tmtypes.Block
+	pending []*tmtypes.Block
+}
+
+func (f *fakeChain) Block(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	if height == nil {
+		if len(f.pending) > 0 {
+			f.blocks, f.pending = append(f.blocks, f.pending[0]), f.pending[1:]
+		}
+		return &ctypes.ResultBlock{Block: f.blocks[len(f.blocks)-1]}, nil
+	}
+	return &ctypes.ResultBlock{Block: f.blocks[*height-1]}, nil
+}
+
+func (f *fakeChain) BlockResults(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	results := &ctypes.ResultBlockResults{Height: *height}
+	for range f.blocks[*height-1].Txs {
+		results.TxsResults = append(results.TxsResults, &abci.ResponseDeliverTx{Code: 5})
+	}
+	return results, nil
+}
+
+func TestWaitForTx(t *testing.T) {
+	ctx := context.Background()
+	hash := []byte{0xab, 0xcd}
+	txDecoder := func(txBytes []byte) (sdk.Tx, error) {
+		return myFakeTx{[]myFakeMsg{{"hello"}}}, nil
+	}
+	txNotFound := func(context.Context, []byte, bool) (*ctypes.ResultTx, error) {
+		return nil, errExpected
+	}
+
+	t.Run("resolves on tx event", func(t *testing.T) {
+		sub := &fakeSubscriber{
+			fakeBroadcaster: fakeBroadcaster{tx: txNotFound},
+			events:          make(chan ctypes.ResultEvent, 1),
+		}
+		sub.events <- ctypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: abci.TxResult{Height: 42}}}
+
+		res, err := waitForTx(ctx, sub, decodeTxResult(txDecoder), hash, txWaitConfig{timeout: time.Second, txIndexEnabled: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), res.Height)
+		assert.Equal(t, "tm.event='Tx' AND tx.hash='ABCD'", sub.query)
+	})
+
+	t.Run("falls back to polling when subscription closes", func(t *testing.T) {
+		sub := &fakeSubscriber{
+			fakeBroadcaster: fakeBroadcaster{tx: txNotFound},
+			events:          make(chan ctypes.ResultEvent),
+		}
+		close(sub.events)
+		polls := 0
+		sub.tx = func(context.Context, []byte, bool) (*ctypes.ResultTx, error) {
+			// the first query covers txs included before subscribing
+			if polls++; polls < 3 {
+				return nil, errExpected
+			}
+			return &ctypes.ResultTx{Height: 7}, nil
+		}
+
+		res, err := waitForTx(ctx, sub, decodeTxResult(txDecoder), hash, txWaitConfig{timeout: time.Second, pollInterval: time.Millisecond, txIndexEnabled: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), res.Height)
+		assert.Equal(t, 3, polls)
+	})
+
+	t.Run("finds a tx included before subscribing without tx index", func(t *testing.T) {
+		tx := tmtypes.Tx("tx")
+		chain := &fakeChain{
+			fakeSubscriber: &fakeSubscriber{events: make(chan ctypes.ResultEvent)},
+		}
+		for height := int64(1); height <= 4; height++ {
+			block := &tmtypes.Block{Header: tmtypes.Header{Height: height}}
+			if height == 3 {
+				block.Data.Txs = tmtypes.Txs{tmtypes.Tx("other"), tx}
+			}
+			chain.blocks = append(chain.blocks, block)
+		}
+
+		res, err := waitForTx(ctx, chain, decodeTxResult(txDecoder), tx.Hash(), txWaitConfig{timeout: time.Second, fromHeight: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res.Height)
+		assert.Equal(t, uint32(5), res.Code)
+
+		// The tx isn't searched in the blocks before the broadcast
+		_, err = waitForTx(ctx, chain, decodeTxResult(txDecoder), tx.Hash(), txWaitConfig{timeout: 10 * time.Millisecond, fromHeight: 3})
+		assert.ErrorIs(t, err, ErrTimeoutAfterWaitingForTxBroadcast)
+	})
+
+	t.Run("polls the new blocks when subscription closes without tx index", func(t *testing.T) {
+		tx := tmtypes.Tx("tx")
+		chain := &fakeChain{
+			fakeSubscriber: &fakeSubscriber{events: make(chan ctypes.ResultEvent)},
+		}
+		close(chain.events)
+		for height := int64(1); height <= 5; height++ {
+			block := &tmtypes.Block{Header: tmtypes.Header{Height: height}}
+			if height == 5 {
+				block.Data.Txs = tmtypes.Txs{tx}
+			}
+			if height <= 2 {
+				chain.blocks = append(chain.blocks, block)
+			} else {
+				chain.pending = append(chain.pending, block)
+			}
+		}
+
+		res, err := waitForTx(ctx, chain, decodeTxResult(txDecoder), tx.Hash(), txWaitConfig{timeout: time.Second, pollInterval: time.Millisecond, fromHeight: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), res.Height)
+		assert.Empty(t, chain.pending)
+	})
+
+	t.Run("fails fast without websocket and tx index", func(t *testing.T) {
+		_, err := waitForTx(ctx, fakeBroadcaster{tx: txNotFound}, decodeTxResult(txDecoder), hash, txWaitConfig{timeout: time.Minute})
+		assert.ErrorIs(t, err, ErrTxIndexingDisabled)
+	})
+}
+
+func TestBroadcastTxModes(t *testing.T) {
+	ctx := context.Background()
+	txBytes := []byte(`tx`)
+	hash := tmbytes.HexBytes(`123bob`)
+
+	for _, tt := range []struct {
+		name       string
+		configMode string
+		opts       []TxOption
+		setup      func(mc *mocks.Client)
+		expected   *sdk.TxResponse
+		expectErr  bool
+	}{
+		{
+			name:       "async from config",
+			configMode: BroadcastModeAsync,
+			setup: func(mc *mocks.Client) {
+				mc.On("BroadcastTxAsync", mock.Anything, tmtypes.Tx(txBytes)).Return(&ctypes.ResultBroadcastTx{Hash: hash}, nil)
+			},
+			expected: &sdk.TxResponse{TxHash: hash.String()},
+		},
+		{
+			name: "sync from option",
+			opts: []TxOption{WithBroadcastMode(BroadcastModeSync)},
+			setup: func(mc *mocks.Client) {
+				mc.On("BroadcastTxSync", mock.Anything, tmtypes.Tx(txBytes)).Return(&ctypes.ResultBroadcastTx{Hash: hash, Log: "[]"}, nil)
+			},
+			expected: &sdk.TxResponse{TxHash: hash.String(), RawLog: "[]"},
+		},
+		{
+			name:       "option overrides config",
+			configMode: BroadcastModeCommit,
+			opts:       []TxOption{WithBroadcastMode(BroadcastModeAsync)},
+			setup: func(mc *mocks.Client) {
+				mc.On("BroadcastTxAsync", mock.Anything, tmtypes.Tx(txBytes)).Return(&ctypes.ResultBroadcastTx{Hash: hash}, nil)
+			},
+			expected: &sdk.TxResponse{TxHash: hash.String()},
+		},
+		{
+			name:      "unknown mode",
+			opts:      []TxOption{WithBroadcastMode("block")},
+			setup:     func(mc *mocks.Client) {},
+			expectErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := new(mocks.Client)
+			tt.setup(mc)
+			cc := &ChainClient{
+				Config:    &ChainClientConfig{BroadcastMode: tt.configMode},
+				RPCClient: mc,
+			}
+
+			res, err := cc.BroadcastTx(ctx, txBytes, tt.opts...)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+			mc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMkTxResult(t *testing.T) {
+	txDecoder := func(txBytes []byte) (sdk.Tx, error) {
+		return myFakeTx{[]myFakeMsg{{"hello"}}}, nil
+	}
+	blockTime := time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	res, err := mkTxResult(txDecoder, &ctypes.ResultTx{
+		Height: 10,
+		TxResult: abci.ResponseDeliverTx{
+			Events: []abci.Event{{
+				Type:       "message",
+				Attributes: []abci.EventAttribute{{Key: "c2VuZGVy", Value: "Y29zbW9zMWFiYw==", Index: true}},
+			}},
+		},
+	}, blockTime)
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-06-01T12:30:00Z", res.Timestamp)
+	assert.Equal(t, []abci.Event{{
+		Type:       "message",
+		Attributes: []abci.EventAttribute{{Key: "sender", Value: "cosmos1abc", Index: true}},
+	}}, res.Events)
+
+	// Without the block time the tx is still returned, untimestamped
+	res, err = mkTxResult(txDecoder, &ctypes.ResultTx{Height: 10}, time.Time{})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Timestamp)
+}
+
+func TestBlockTime(t *testing.T) {
+	ctx := context.Background()
+	blockTime := time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC)
+	height := int64(10)
+
+	mc := new(mocks.Client)
+	mc.On("Header", mock.Anything, &height).Return(&ctypes.ResultHeader{
+		Header: &tmtypes.Header{Height: height, Time: blockTime},
+	}, nil).Once()
+	cc := &ChainClient{RPCClient: mc}
+
+	// Txs in the same block only query the header once.
+	for i := 0; i < 2; i++ {
+		got, err := cc.BlockTime(ctx, height)
+		assert.NoError(t, err)
+		assert.Equal(t, blockTime, got)
+	}
+	mc.AssertExpectations(t)
+}
@@ -41,9 +41,14 @@ type ChainClient struct {
 	LightProvider  provtypes.Provider
 	Input          io.Reader
 	Output         io.Writer
-	// TODO: GRPC Client type?
 
 	Codec Codec
+
+	sequences  sequenceManager
+	txIndex    txIndexStatus
+	blockTimes blockTimeCache
+	grpcConn   grpcConnState
+	light      lightClientState
 }
 
 func NewChainClient(log *zap.Logger, ccc *ChainClientConfig, homepath string, input io.Reader, output io.Writer, kro ...keyring.Option) (*ChainClient, error) {
@@ -72,14 +77,26 @@ func (cc *ChainClient) Init() error {
 	// TODO: figure out how to deal with input or maybe just make all keyring backends test?
 
 	timeout, _ := time.ParseDuration(cc.Config.Timeout)
-	rpcClient, err := NewRPCClient(cc.Config.RPCAddr, timeout)
-	if err != nil {
-		return err
-	}
-
-	lightprovider, err := prov.New(cc.Config.ChainID, cc.Config.RPCAddr)
-	if err != nil {
-		return err
+	var (
+		rpcClient     rpcclient.Client
+		lightprovider provtypes.Provider
+	)
+	if addrs := cc.Config.rpcAddrs(); len(addrs) > 1 {
+		// Fail over between the endpoints if there are several, the light provider
+		// follows the best one
+		pool, err := NewEndpointPool(cc.log, addrs, timeout)
+		if err != nil {
+			return err
+		}
+		rpcClient = pool
+		lightprovider = prov.NewWithClient(cc.Config.ChainID, pool)
+	} else {
+		if rpcClient, err = NewRPCClient(cc.Config.RPCAddr, timeout); err != nil {
+			return err
+		}
+		if lightprovider, err = prov.New(cc.Config.ChainID, cc.Config.RPCAddr); err != nil {
+			return err
+		}
 	}
 
 	cc.RPCClient = rpcClient
@@ -89,6 +106,26 @@ func (cc *ChainClient) Init() error {
 	return nil
 }
 
+// Close releases the connections of the client: the gRPC connection, the health checks of
+// an EndpointPool and the websocket of the RPC client. Close is final: a stopped RPC client
+// can't be started again, so a new client must be made to query the chain after it.
+func (cc *ChainClient) Close() error {
+	err := cc.closeGRPCConn()
+	switch rpcClient := cc.RPCClient.(type) {
+	case *EndpointPool:
+		if closeErr := rpcClient.Close(); err == nil {
+			err = closeErr
+		}
+	case *rpchttp.HTTP:
+		if rpcClient.IsRunning() {
+			if stopErr := rpcClient.Stop(); err == nil {
+				err = stopErr
+			}
+		}
+	}
+	return err
+}
+
 func (cc *ChainClient) GetKeyAddress() (sdk.AccAddress, error) {
 	info, err := cc.Keybase.Key(cc.Config.Key)
 	if err != nil {
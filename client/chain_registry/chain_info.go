@@ -3,12 +3,12 @@ package chain_registry
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/spf13/viper"
@@ -99,16 +99,8 @@ func IsHealthyRPC(ctx context.Context, endpoint string) error {
 	if err != nil {
 		return err
 	}
-	stat, err := cl.Status(ctx)
-	if err != nil {
-		return err
-	}
-
-	if stat.SyncInfo.CatchingUp {
-		return errors.New("still catching up")
-	}
-
-	return nil
+	_, err = client.CheckRPCHealth(ctx, cl)
+	return err
 }
 
 func (c ChainInfo) GetRPCEndpoints(ctx context.Context) (out []string, err error) {
@@ -118,6 +110,7 @@ func (c ChainInfo) GetRPCEndpoints(ctx context.Context) (out []string, err error
 	}
 
 	var eg errgroup.Group
+	var mu sync.Mutex
 	var endpoints []string
 	healthy := 0
 	unhealthy := 0
@@ -125,6 +118,8 @@ func (c ChainInfo) GetRPCEndpoints(ctx context.Context) (out []string, err error
 		endpoint := endpoint
 		eg.Go(func() error {
 			err := IsHealthyRPC(ctx, endpoint)
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
 				unhealthy += 1
 				c.log.Debug(
@@ -152,22 +147,32 @@ func (c ChainInfo) GetRPCEndpoints(ctx context.Context) (out []string, err error
 }
 
 func (c ChainInfo) GetRandomRPCEndpoint(ctx context.Context) (string, error) {
-	rpcs, err := c.GetRPCEndpoints(ctx)
+	rpcs, err := c.getShuffledRPCEndpoints(ctx)
 	if err != nil {
 		return "", err
 	}
+	return rpcs[0], nil
+}
+
+// getShuffledRPCEndpoints returns the healthy RPC endpoints in random order, so the first
+// endpoint of the clients of the chain is spread over them.
+func (c ChainInfo) getShuffledRPCEndpoints(ctx context.Context) ([]string, error) {
+	rpcs, err := c.GetRPCEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	if len(rpcs) == 0 {
-		return "", fmt.Errorf("no working RPCs found")
+		return nil, fmt.Errorf("no working RPCs found")
 	}
 
 	randomGenerator := rand.New(rand.NewSource(time.Now().UnixNano()))
-	endpoint := rpcs[randomGenerator.Intn(len(rpcs))]
+	randomGenerator.Shuffle(len(rpcs), func(i, j int) { rpcs[i], rpcs[j] = rpcs[j], rpcs[i] })
 	c.log.Info("Endpoint selected",
 		zap.String("chain_name", c.ChainName),
-		zap.String("endpoint", endpoint),
+		zap.String("endpoint", rpcs[0]),
 	)
-	return endpoint, nil
+	return rpcs, nil
 }
 
 func (c ChainInfo) GetAssetList(ctx context.Context) (AssetList, error) {
@@ -212,7 +217,7 @@ func (c ChainInfo) GetChainConfig(ctx context.Context) (*client.ChainClientConfi
 		gasPrices = fmt.Sprintf("%.2f%s", 0.01, assetList.Assets[0].Base)
 	}
 
-	rpc, err := c.GetRandomRPCEndpoint(ctx)
+	rpcs, err := c.getShuffledRPCEndpoints(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -220,7 +225,8 @@ func (c ChainInfo) GetChainConfig(ctx context.Context) (*client.ChainClientConfi
 	return &client.ChainClientConfig{
 		Key:            "default",
 		ChainID:        c.ChainID,
-		RPCAddr:        rpc,
+		RPCAddr:        rpcs[0],
+		RPCAddrs:       rpcs[1:],
 		AccountPrefix:  c.Bech32Prefix,
 		KeyringBackend: "test",
 		GasAdjustment:  1.2,
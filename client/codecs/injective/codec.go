@@ -3,6 +3,7 @@ package injective
 import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 )
 
@@ -37,4 +38,11 @@ func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
 		(*ExtensionOptionsWeb3TxI)(nil),
 		&ExtensionOptionsWeb3Tx{},
 	)
+
+	// Decoding a tx unpacks its extension options as TxExtensionOptionI
+	registry.RegisterImplementations(
+		(*tx.TxExtensionOptionI)(nil),
+		&ExtensionOptionsWeb3Tx{},
+		&ExtensionOptionsEthereumTx{},
+	)
 }
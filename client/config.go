@@ -1,8 +1,12 @@
 package client
 
 import (
+	"encoding/hex"
+	"fmt"
 	"time"
 
+	"github.com/cometbft/cometbft/light"
+
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	authz "github.com/cosmos/cosmos-sdk/x/authz/module"
@@ -22,8 +26,12 @@ import (
 	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 	"github.com/cosmos/ibc-go/v7/modules/apps/transfer"
 	ibc "github.com/cosmos/ibc-go/v7/modules/core"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
 )
 
+// defaultTrustingPeriod is two thirds of the default unbonding period of three weeks.
+const defaultTrustingPeriod = 14 * 24 * time.Hour
+
 var (
 	ModuleBasics = []module.AppModuleBasic{
 		auth.AppModuleBasic{},
@@ -49,28 +57,42 @@ var (
 		upgrade.AppModuleBasic{},
 		transfer.AppModuleBasic{},
 		ibc.AppModuleBasic{},
+		ibctm.AppModuleBasic{},
 	}
 )
 
 type ChainClientConfig struct {
-	Key            string                  `json:"key" yaml:"key"`
-	ChainID        string                  `json:"chain-id" yaml:"chain-id"`
-	RPCAddr        string                  `json:"rpc-addr" yaml:"rpc-addr"`
-	GRPCAddr       string                  `json:"grpc-addr" yaml:"grpc-addr"`
-	AccountPrefix  string                  `json:"account-prefix" yaml:"account-prefix"`
-	KeyringBackend string                  `json:"keyring-backend" yaml:"keyring-backend"`
-	GasAdjustment  float64                 `json:"gas-adjustment" yaml:"gas-adjustment"`
-	GasPrices      string                  `json:"gas-prices" yaml:"gas-prices"`
-	MinGasAmount   uint64                  `json:"min-gas-amount" yaml:"min-gas-amount"`
-	KeyDirectory   string                  `json:"key-directory" yaml:"key-directory"`
-	Debug          bool                    `json:"debug" yaml:"debug"`
-	Timeout        string                  `json:"timeout" yaml:"timeout"`
-	BlockTimeout   string                  `json:"block-timeout" yaml:"block-timeout"`
-	OutputFormat   string                  `json:"output-format" yaml:"output-format"`
-	SignModeStr    string                  `json:"sign-mode" yaml:"sign-mode"`
-	ExtraCodecs    []string                `json:"extra-codecs" yaml:"extra-codecs"`
-	Modules        []module.AppModuleBasic `json:"-" yaml:"-"`
-	Slip44         int                     `json:"slip44" yaml:"slip44"`
+	Key               string                  `json:"key" yaml:"key"`
+	ChainID           string                  `json:"chain-id" yaml:"chain-id"`
+	RPCAddr           string                  `json:"rpc-addr" yaml:"rpc-addr"`
+	GRPCAddr          string                  `json:"grpc-addr" yaml:"grpc-addr"`
+	AccountPrefix     string                  `json:"account-prefix" yaml:"account-prefix"`
+	KeyringBackend    string                  `json:"keyring-backend" yaml:"keyring-backend"`
+	GasAdjustment     float64                 `json:"gas-adjustment" yaml:"gas-adjustment"`
+	GasPrices         string                  `json:"gas-prices" yaml:"gas-prices"`
+	MinGasAmount      uint64                  `json:"min-gas-amount" yaml:"min-gas-amount"`
+	KeyDirectory      string                  `json:"key-directory" yaml:"key-directory"`
+	Debug             bool                    `json:"debug" yaml:"debug"`
+	Timeout           string                  `json:"timeout" yaml:"timeout"`
+	BlockTimeout      string                  `json:"block-timeout" yaml:"block-timeout"`
+	BroadcastMode     string                  `json:"broadcast-mode" yaml:"broadcast-mode"`
+	TxPollInterval    string                  `json:"tx-poll-interval" yaml:"tx-poll-interval"`
+	TxPollMaxInterval string                  `json:"tx-poll-max-interval" yaml:"tx-poll-max-interval"`
+	OutputFormat      string                  `json:"output-format" yaml:"output-format"`
+	SignModeStr       string                  `json:"sign-mode" yaml:"sign-mode"`
+	ExtraCodecs       []string                `json:"extra-codecs" yaml:"extra-codecs"`
+	Modules           []module.AppModuleBasic `json:"-" yaml:"-"`
+	Slip44            int                     `json:"slip44" yaml:"slip44"`
+	// RPCAddrs and GRPCAddrs are more endpoints of the chain, requests fail over to
+	// them when RPCAddr or GRPCAddr are down.
+	RPCAddrs  []string `json:"rpc-addrs,omitempty" yaml:"rpc-addrs,omitempty"`
+	GRPCAddrs []string `json:"grpc-addrs,omitempty" yaml:"grpc-addrs,omitempty"`
+	// TrustedHeight and TrustedHash are a header of the chain that the light client
+	// verifying proven queries trusts, TrustingPeriod how long it trusts a header for.
+	TrustedHeight  int64    `json:"trusted-height,omitempty" yaml:"trusted-height,omitempty"`
+	TrustedHash    string   `json:"trusted-hash,omitempty" yaml:"trusted-hash,omitempty"`
+	TrustingPeriod string   `json:"trusting-period,omitempty" yaml:"trusting-period,omitempty"`
+	LightWitnesses []string `json:"light-witnesses,omitempty" yaml:"light-witnesses,omitempty"`
 }
 
 func (ccc *ChainClientConfig) Validate() error {
@@ -82,9 +104,72 @@ func (ccc *ChainClientConfig) Validate() error {
 			return err
 		}
 	}
+	if ccc.TxPollInterval != "" {
+		if _, err := time.ParseDuration(ccc.TxPollInterval); err != nil {
+			return err
+		}
+	}
+	if ccc.TxPollMaxInterval != "" {
+		if _, err := time.ParseDuration(ccc.TxPollMaxInterval); err != nil {
+			return err
+		}
+	}
+	if err := validateBroadcastMode(ccc.BroadcastMode); err != nil {
+		return err
+	}
+	if ccc.TrustedHeight != 0 || ccc.TrustedHash != "" {
+		if _, err := ccc.TrustOptions(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// rpcAddrs returns RPCAddr and RPCAddrs without duplicates.
+func (ccc *ChainClientConfig) rpcAddrs() []string {
+	return uniqueAddrs(append([]string{ccc.RPCAddr}, ccc.RPCAddrs...))
+}
+
+// grpcAddrs returns GRPCAddr and GRPCAddrs without duplicates.
+func (ccc *ChainClientConfig) grpcAddrs() []string {
+	return uniqueAddrs(append([]string{ccc.GRPCAddr}, ccc.GRPCAddrs...))
+}
+
+func uniqueAddrs(addrs []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		if addr != "" && !seen[addr] {
+			seen[addr] = true
+			out = append(out, addr)
+		}
+	}
+	return out
+}
+
+// TrustOptions returns the options of the light client verifying proven queries, from
+// the trusted header and trusting period of the config.
+func (ccc *ChainClientConfig) TrustOptions() (light.TrustOptions, error) {
+	if ccc.TrustedHeight == 0 && ccc.TrustedHash == "" {
+		return light.TrustOptions{}, ErrNoTrustedHeader
+	}
+	hash, err := hex.DecodeString(ccc.TrustedHash)
+	if err != nil {
+		return light.TrustOptions{}, fmt.Errorf("invalid trusted-hash: %w", err)
+	}
+	period := defaultTrustingPeriod
+	if ccc.TrustingPeriod != "" {
+		if period, err = time.ParseDuration(ccc.TrustingPeriod); err != nil {
+			return light.TrustOptions{}, fmt.Errorf("invalid trusting-period: %w", err)
+		}
+	}
+	opts := light.TrustOptions{Period: period, Height: ccc.TrustedHeight, Hash: hash}
+	if err := opts.ValidateBasic(); err != nil {
+		return light.TrustOptions{}, fmt.Errorf("invalid trusted header: %w", err)
+	}
+	return opts, nil
+}
+
 func GetCosmosHubConfig(keyHome string, debug bool) *ChainClientConfig {
 	return &ChainClientConfig{
 		Key:            "default",
@@ -0,0 +1,613 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/libs/log"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// healthCheckInterval is how often the health of the endpoints of a pool is checked.
+	healthCheckInterval = 30 * time.Second
+	// healthCheckTimeout is the timeout of the status request checking an endpoint.
+	healthCheckTimeout = 5 * time.Second
+	// maxHeightLag is how many blocks an endpoint may be behind the highest endpoint of
+	// its pool and still be preferred.
+	maxHeightLag = 5
+	// circuitFailures is how many failures in a row open the circuit of an endpoint.
+	circuitFailures = 3
+	// circuitCooldown is how long an open circuit keeps an endpoint out of rotation.
+	circuitCooldown = 30 * time.Second
+)
+
+// CheckRPCHealth returns the status of the node behind an RPC client, and an error if it
+// can't be reached or is still catching up.
+func CheckRPCHealth(ctx context.Context, cl rpcclient.StatusClient) (*coretypes.ResultStatus, error) {
+	stat, err := cl.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if stat.SyncInfo.CatchingUp {
+		return stat, errors.New("still catching up")
+	}
+	return stat, nil
+}
+
+// endpointHealth is the health score and circuit breaker of an endpoint.
+type endpointHealth struct {
+	mu         sync.Mutex
+	latency    time.Duration
+	height     int64
+	catchingUp bool
+	failures   int
+	openUntil  time.Time
+	lastErr    error
+}
+
+// succeed closes the circuit of the endpoint.
+func (h *endpointHealth) succeed() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures = 0
+	h.openUntil = time.Time{}
+	h.lastErr = nil
+}
+
+// fail counts a failure of the endpoint, the circuit opens for cooldown once there were
+// threshold failures in a row. A failure after the cooldown opens it again right away.
+func (h *endpointHealth) fail(err error, threshold int, cooldown time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures++
+	h.lastErr = err
+	if h.failures >= threshold {
+		h.openUntil = time.Now().Add(cooldown)
+	}
+}
+
+func (h *endpointHealth) open(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return now.Before(h.openUntil)
+}
+
+// EndpointHealth is the last known health of an endpoint of an EndpointPool.
+type EndpointHealth struct {
+	Address    string        `json:"address" yaml:"address"`
+	Latency    time.Duration `json:"latency" yaml:"latency"`
+	Height     int64         `json:"height" yaml:"height"`
+	CatchingUp bool          `json:"catching_up" yaml:"catching_up"`
+	Failures   int           `json:"failures" yaml:"failures"`
+	Open       bool          `json:"circuit_open" yaml:"circuit_open"`
+	Error      string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type rpcEndpoint struct {
+	addr   string
+	client *rpchttp.HTTP
+	health endpointHealth
+}
+
+var _ rpcclient.RemoteClient = &EndpointPool{}
+
+// EndpointPool is an RPC client sending each request to the best of several RPC endpoints
+// of a chain, and failing over to the next best one if an endpoint can't be reached.
+//
+// Endpoints are ranked by their health, checked with a status request in the background
+// every healthCheckInterval until the pool is closed: endpoints that are neither catching up nor
+// more than maxHeightLag blocks behind the others come first, ordered by latency. An
+// endpoint failing its health check, or circuitFailures requests in a row, is left out
+// for circuitCooldown unless all the others are failing too.
+//
+// Broadcasts only fail over while they couldn't be sent to an endpoint, so a tx is never
+// broadcast twice.
+type EndpointPool struct {
+	log       *zap.Logger
+	endpoints []*rpcEndpoint
+
+	threshold int
+	cooldown  time.Duration
+
+	done      chan struct{}
+	stopped   sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewEndpointPool returns a pool of the RPC endpoints at addrs, duplicate addresses are
+// ignored. timeout is the timeout of the requests to each endpoint. The pool checks the
+// health of the endpoints in the background until it is closed.
+func NewEndpointPool(log *zap.Logger, addrs []string, timeout time.Duration) (*EndpointPool, error) {
+	return newEndpointPool(log, addrs, timeout, healthCheckInterval)
+}
+
+// newEndpointPool returns a pool checking the health of its endpoints every interval, or
+// only when CheckHealth is called if interval is 0.
+func newEndpointPool(log *zap.Logger, addrs []string, timeout, interval time.Duration) (*EndpointPool, error) {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	p := &EndpointPool{
+		log:       log,
+		threshold: circuitFailures,
+		cooldown:  circuitCooldown,
+		done:      make(chan struct{}),
+	}
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		cl, err := NewRPCClient(addr, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RPC endpoint %s: %w", addr, err)
+		}
+		p.endpoints = append(p.endpoints, &rpcEndpoint{addr: addr, client: cl})
+	}
+	if len(p.endpoints) == 0 {
+		return nil, errors.New("no RPC endpoints configured")
+	}
+	if interval > 0 {
+		p.stopped.Add(1)
+		go p.checkHealthEvery(interval)
+	}
+	return p, nil
+}
+
+// Close stops the health checks of the pool and the websockets of its endpoints.
+func (p *EndpointPool) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+	p.stopped.Wait()
+	return p.Stop()
+}
+
+// checkHealthEvery checks the health of the endpoints right away, then every interval until
+// the pool is closed.
+func (p *EndpointPool) checkHealthEvery(interval time.Duration) {
+	defer p.stopped.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.done
+		cancel()
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.CheckHealth(ctx)
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckHealth checks the health of all the endpoints of the pool and waits for the checks.
+func (p *EndpointPool) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func(e *rpcEndpoint) {
+			defer wg.Done()
+			p.checkEndpoint(ctx, e)
+		}(e)
+	}
+	wg.Wait()
+}
+
+func (p *EndpointPool) checkEndpoint(ctx context.Context, e *rpcEndpoint) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	stat, err := CheckRPCHealth(ctx, e.client)
+	latency := time.Since(start)
+	if stat == nil {
+		// The endpoint is down, it is left out without waiting for requests to fail
+		p.log.Debug("RPC endpoint health check failed", zap.String("endpoint", e.addr), zap.Error(err))
+		e.health.fail(err, 1, p.cooldown)
+		return
+	}
+
+	e.health.succeed()
+	e.health.mu.Lock()
+	e.health.latency = latency
+	e.health.height = stat.SyncInfo.LatestBlockHeight
+	e.health.catchingUp = stat.SyncInfo.CatchingUp
+	e.health.mu.Unlock()
+}
+
+// Endpoints returns the last known health of the endpoints of the pool, best endpoint first.
+func (p *EndpointPool) Endpoints() []EndpointHealth {
+	now := time.Now()
+	ranked := p.ranked()
+	out := make([]EndpointHealth, 0, len(ranked))
+	for _, e := range ranked {
+		e.health.mu.Lock()
+		h := EndpointHealth{
+			Address:    e.addr,
+			Latency:    e.health.latency,
+			Height:     e.health.height,
+			CatchingUp: e.health.catchingUp,
+			Failures:   e.health.failures,
+			Open:       now.Before(e.health.openUntil),
+		}
+		if e.health.lastErr != nil {
+			h.Error = e.health.lastErr.Error()
+		}
+		e.health.mu.Unlock()
+		out = append(out, h)
+	}
+	return out
+}
+
+// ranked returns the endpoints of the pool, best first. Endpoints that were never checked
+// keep the order of the config after the checked ones of the same tier.
+func (p *EndpointPool) ranked() []*rpcEndpoint {
+	type score struct {
+		e       *rpcEndpoint
+		tier    int
+		latency time.Duration
+	}
+	now := time.Now()
+	var maxHeight int64
+	for _, e := range p.endpoints {
+		e.health.mu.Lock()
+		if e.health.height > maxHeight {
+			maxHeight = e.health.height
+		}
+		e.health.mu.Unlock()
+	}
+
+	scores := make([]score, len(p.endpoints))
+	for i, e := range p.endpoints {
+		e.health.mu.Lock()
+		s := score{e: e, latency: e.health.latency}
+		switch {
+		case now.Before(e.health.openUntil):
+			s.tier = 2
+		case e.health.catchingUp, e.health.height > 0 && e.health.height < maxHeight-maxHeightLag:
+			s.tier = 1
+		}
+		e.health.mu.Unlock()
+		scores[i] = s
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		a, b := scores[i], scores[j]
+		if a.tier != b.tier {
+			return a.tier < b.tier
+		}
+		if (a.latency == 0) != (b.latency == 0) {
+			return b.latency == 0
+		}
+		return a.latency < b.latency
+	})
+
+	out := make([]*rpcEndpoint, len(scores))
+	for i, s := range scores {
+		out[i] = s.e
+	}
+	return out
+}
+
+// best returns the best endpoint of the pool.
+func (p *EndpointPool) best() *rpcEndpoint {
+	return p.ranked()[0]
+}
+
+// Addresses returns the addresses of the endpoints of the pool, best first.
+func (p *EndpointPool) Addresses() []string {
+	ranked := p.ranked()
+	addrs := make([]string, len(ranked))
+	for i, e := range ranked {
+		addrs[i] = e.addr
+	}
+	return addrs
+}
+
+// Remote returns the address of the best endpoint of the pool.
+func (p *EndpointPool) Remote() string {
+	return p.best().addr
+}
+
+// failover returns whether a request that failed with err may succeed on another endpoint.
+// Errors returned by the node itself, and errors of a done context, don't fail over.
+func failover(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var rpcErr *rpctypes.RPCError
+	return !errors.As(err, &rpcErr)
+}
+
+// unsent returns whether a request that failed with err was never sent to the endpoint,
+// because the connection to it couldn't be made.
+func unsent(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// poolCall sends a request to the best endpoint of the pool, and to the next best ones
+// while it fails with an error that may succeed on another endpoint.
+func poolCall[T any](ctx context.Context, p *EndpointPool, call func(*rpchttp.HTTP) (T, error)) (T, error) {
+	return poolCallWith(ctx, p, failover, call)
+}
+
+// poolBroadcast sends a request that must not reach several endpoints, like a broadcast, to
+// the best endpoint of the pool, and to the next best ones only while it couldn't be sent.
+func poolBroadcast[T any](ctx context.Context, p *EndpointPool, call func(*rpchttp.HTTP) (T, error)) (T, error) {
+	return poolCallWith(ctx, p, unsent, call)
+}
+
+// poolCallWith sends a request to the endpoints of the pool, best first, while it fails with
+// an error for which retry is true.
+func poolCallWith[T any](ctx context.Context, p *EndpointPool, retry func(context.Context, error) bool, call func(*rpchttp.HTTP) (T, error)) (T, error) {
+	var (
+		zero    T
+		lastErr error
+	)
+	for _, e := range p.ranked() {
+		res, err := call(e.client)
+		if err == nil || !retry(ctx, err) {
+			if err == nil {
+				e.health.succeed()
+			}
+			return res, err
+		}
+		p.log.Debug("RPC endpoint failed, failing over", zap.String("endpoint", e.addr), zap.Error(err))
+		e.health.fail(err, p.threshold, p.cooldown)
+		lastErr = fmt.Errorf("%s: %w", e.addr, err)
+	}
+	return zero, fmt.Errorf("all %d RPC endpoints failed, last error: %w", len(p.endpoints), lastErr)
+}
+
+// Service methods, starting the pool starts the websocket of every endpoint to subscribe
+// to events with.
+
+func (p *EndpointPool) Start() error {
+	var started int
+	var lastErr error
+	for _, e := range p.endpoints {
+		if err := e.client.Start(); err != nil {
+			p.log.Debug("Failed to start RPC endpoint", zap.String("endpoint", e.addr), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		started++
+	}
+	if started == 0 {
+		return fmt.Errorf("failed to start any RPC endpoint: %w", lastErr)
+	}
+	return nil
+}
+
+func (p *EndpointPool) OnStart() error { return nil }
+
+func (p *EndpointPool) Stop() error {
+	for _, e := range p.endpoints {
+		if e.client.IsRunning() {
+			if err := e.client.Stop(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *EndpointPool) OnStop() {}
+
+func (p *EndpointPool) Reset() error { return errors.New("an endpoint pool can't be reset") }
+
+func (p *EndpointPool) OnReset() error { return nil }
+
+func (p *EndpointPool) IsRunning() bool {
+	for _, e := range p.endpoints {
+		if e.client.IsRunning() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *EndpointPool) Quit() <-chan struct{} { return p.endpoints[0].client.Quit() }
+
+func (p *EndpointPool) String() string { return "EndpointPool" }
+
+func (p *EndpointPool) SetLogger(l log.Logger) {
+	for _, e := range p.endpoints {
+		e.client.SetLogger(l)
+	}
+}
+
+// Events methods, subscriptions are made on the best running endpoint.
+
+func (p *EndpointPool) eventsEndpoint() *rpcEndpoint {
+	for _, e := range p.ranked() {
+		if e.client.IsRunning() {
+			return e
+		}
+	}
+	return p.best()
+}
+
+func (p *EndpointPool) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan coretypes.ResultEvent, error) {
+	return p.eventsEndpoint().client.Subscribe(ctx, subscriber, query, outCapacity...)
+}
+
+func (p *EndpointPool) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	for _, e := range p.endpoints {
+		if e.client.IsRunning() {
+			_ = e.client.Unsubscribe(ctx, subscriber, query)
+		}
+	}
+	return nil
+}
+
+func (p *EndpointPool) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	for _, e := range p.endpoints {
+		if e.client.IsRunning() {
+			_ = e.client.UnsubscribeAll(ctx, subscriber)
+		}
+	}
+	return nil
+}
+
+// ABCI methods
+
+func (p *EndpointPool) ABCIInfo(ctx context.Context) (*coretypes.ResultABCIInfo, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultABCIInfo, error) { return c.ABCIInfo(ctx) })
+}
+
+func (p *EndpointPool) ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (*coretypes.ResultABCIQuery, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultABCIQuery, error) { return c.ABCIQuery(ctx, path, data) })
+}
+
+func (p *EndpointPool) ABCIQueryWithOptions(ctx context.Context, path string, data bytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultABCIQuery, error) {
+		return c.ABCIQueryWithOptions(ctx, path, data, opts)
+	})
+}
+
+func (p *EndpointPool) BroadcastTxCommit(ctx context.Context, tx tmtypes.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
+	return poolBroadcast(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBroadcastTxCommit, error) { return c.BroadcastTxCommit(ctx, tx) })
+}
+
+func (p *EndpointPool) BroadcastTxAsync(ctx context.Context, tx tmtypes.Tx) (*coretypes.ResultBroadcastTx, error) {
+	return poolBroadcast(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBroadcastTx, error) { return c.BroadcastTxAsync(ctx, tx) })
+}
+
+func (p *EndpointPool) BroadcastTxSync(ctx context.Context, tx tmtypes.Tx) (*coretypes.ResultBroadcastTx, error) {
+	return poolBroadcast(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBroadcastTx, error) { return c.BroadcastTxSync(ctx, tx) })
+}
+
+// Sign methods
+
+func (p *EndpointPool) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBlock, error) { return c.Block(ctx, height) })
+}
+
+func (p *EndpointPool) BlockByHash(ctx context.Context, hash []byte) (*coretypes.ResultBlock, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBlock, error) { return c.BlockByHash(ctx, hash) })
+}
+
+func (p *EndpointPool) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBlockResults, error) { return c.BlockResults(ctx, height) })
+}
+
+func (p *EndpointPool) Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultHeader, error) { return c.Header(ctx, height) })
+}
+
+func (p *EndpointPool) HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultHeader, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultHeader, error) { return c.HeaderByHash(ctx, hash) })
+}
+
+func (p *EndpointPool) Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultCommit, error) { return c.Commit(ctx, height) })
+}
+
+func (p *EndpointPool) Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultValidators, error) {
+		return c.Validators(ctx, height, page, perPage)
+	})
+}
+
+func (p *EndpointPool) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.ResultTx, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultTx, error) { return c.Tx(ctx, hash, prove) })
+}
+
+func (p *EndpointPool) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultTxSearch, error) {
+		return c.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	})
+}
+
+func (p *EndpointPool) BlockSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBlockSearch, error) {
+		return c.BlockSearch(ctx, query, page, perPage, orderBy)
+	})
+}
+
+// History methods
+
+func (p *EndpointPool) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultGenesis, error) { return c.Genesis(ctx) })
+}
+
+func (p *EndpointPool) GenesisChunked(ctx context.Context, id uint) (*coretypes.ResultGenesisChunk, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultGenesisChunk, error) { return c.GenesisChunked(ctx, id) })
+}
+
+func (p *EndpointPool) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBlockchainInfo, error) {
+		return c.BlockchainInfo(ctx, minHeight, maxHeight)
+	})
+}
+
+// Network methods
+
+func (p *EndpointPool) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultNetInfo, error) { return c.NetInfo(ctx) })
+}
+
+func (p *EndpointPool) DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultDumpConsensusState, error) { return c.DumpConsensusState(ctx) })
+}
+
+func (p *EndpointPool) ConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultConsensusState, error) { return c.ConsensusState(ctx) })
+}
+
+func (p *EndpointPool) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultConsensusParams, error) { return c.ConsensusParams(ctx, height) })
+}
+
+func (p *EndpointPool) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultHealth, error) { return c.Health(ctx) })
+}
+
+// Status method
+
+func (p *EndpointPool) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultStatus, error) { return c.Status(ctx) })
+}
+
+// Evidence method
+
+func (p *EndpointPool) BroadcastEvidence(ctx context.Context, ev tmtypes.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultBroadcastEvidence, error) { return c.BroadcastEvidence(ctx, ev) })
+}
+
+// Mempool methods
+
+func (p *EndpointPool) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultUnconfirmedTxs, error) { return c.UnconfirmedTxs(ctx, limit) })
+}
+
+func (p *EndpointPool) NumUnconfirmedTxs(ctx context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultUnconfirmedTxs, error) { return c.NumUnconfirmedTxs(ctx) })
+}
+
+func (p *EndpointPool) CheckTx(ctx context.Context, tx tmtypes.Tx) (*coretypes.ResultCheckTx, error) {
+	return poolCall(ctx, p, func(c *rpchttp.HTTP) (*coretypes.ResultCheckTx, error) { return c.CheckTx(ctx, tx) })
+}
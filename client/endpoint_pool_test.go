@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// fakeNode serves the status of a node at height over JSON-RPC, other methods fail with an
+// RPC error returned by the node.
+func fakeNode(t *testing.T, height int64, catchingUp bool) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpctypes.RPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		res := rpctypes.RPCInternalError(req.ID, errMethodNotServed)
+		if req.Method == "status" {
+			status := &coretypes.ResultStatus{}
+			status.SyncInfo.LatestBlockHeight = height
+			status.SyncInfo.CatchingUp = catchingUp
+			res = rpctypes.NewRPCSuccessResponse(req.ID, status)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+var errMethodNotServed = errors.New("method not served")
+
+// downNode returns the address of a node that refuses connections.
+func downNode() string {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	down := downNode()
+	up := fakeNode(t, 100, false)
+	// Don't check the health in the background, the test ranks the endpoints by failures only
+	p, err := newEndpointPool(zaptest.NewLogger(t), []string{down, up.URL, up.URL}, time.Second, 0)
+	require.NoError(t, err)
+	require.Len(t, p.endpoints, 2, "duplicate endpoint")
+
+	ctx := context.Background()
+	for i := 0; i < circuitFailures; i++ {
+		require.Equal(t, down, p.Endpoints()[0].Address, "circuit opened after %d failures", i)
+		status, err := p.Status(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), status.SyncInfo.LatestBlockHeight)
+	}
+
+	// The down endpoint is left out once its circuit is open
+	health := p.Endpoints()
+	require.Equal(t, up.URL, health[0].Address)
+	require.Equal(t, down, health[1].Address)
+	require.True(t, health[1].Open)
+	require.Equal(t, circuitFailures, health[1].Failures)
+
+	// An error returned by the node doesn't fail over to the next endpoint
+	_, err = p.Health(ctx)
+	require.ErrorContains(t, err, "method not served")
+	require.Zero(t, p.Endpoints()[0].Failures)
+
+	// Requests fail if all the endpoints are down
+	p, err = newEndpointPool(zaptest.NewLogger(t), []string{down}, time.Second, 0)
+	require.NoError(t, err)
+	_, err = p.Status(ctx)
+	require.ErrorContains(t, err, "all 1 RPC endpoints failed")
+}
+
+func TestEndpointPoolRanking(t *testing.T) {
+	catchingUp := fakeNode(t, 100, true)
+	lagging := fakeNode(t, 100-maxHeightLag-1, false)
+	healthy := fakeNode(t, 100, false)
+	down := downNode()
+	p, err := newEndpointPool(zaptest.NewLogger(t), []string{down, catchingUp.URL, lagging.URL, healthy.URL}, time.Second, 0)
+	require.NoError(t, err)
+
+	// Endpoints keep the order of the config until they are checked
+	require.Equal(t, down, p.Endpoints()[0].Address)
+
+	p.CheckHealth(context.Background())
+	health := p.Endpoints()
+	require.Equal(t, healthy.URL, health[0].Address)
+	require.True(t, health[1].Address == catchingUp.URL || health[1].Address == lagging.URL)
+	require.Equal(t, down, health[3].Address)
+	require.NotEmpty(t, health[3].Error)
+	require.Equal(t, int64(100), health[0].Height)
+}
+
+func TestEndpointPoolBroadcast(t *testing.T) {
+	var dropped int32
+	// dropping reads the broadcast then drops the connection without answering
+	dropping := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&dropped, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	t.Cleanup(dropping.Close)
+	up := fakeNode(t, 100, false)
+	tx := tmtypes.Tx("tx")
+	ctx := context.Background()
+
+	// A broadcast that couldn't be sent fails over
+	p, err := newEndpointPool(zaptest.NewLogger(t), []string{downNode(), up.URL}, time.Second, 0)
+	require.NoError(t, err)
+	_, err = p.BroadcastTxSync(ctx, tx)
+	require.ErrorContains(t, err, "method not served")
+
+	// A broadcast sent to an endpoint doesn't fail over, it may have reached the mempool
+	p, err = newEndpointPool(zaptest.NewLogger(t), []string{dropping.URL, up.URL}, time.Second, 0)
+	require.NoError(t, err)
+	_, err = p.BroadcastTxSync(ctx, tx)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "method not served")
+	require.Equal(t, int32(1), atomic.LoadInt32(&dropped))
+}
+
+func TestEndpointPoolHealthChecks(t *testing.T) {
+	healthy := fakeNode(t, 100, false)
+	down := downNode()
+	p, err := newEndpointPool(zaptest.NewLogger(t), []string{down, healthy.URL}, time.Second, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	// The endpoints are checked in the background without any request
+	require.Eventually(t, func() bool {
+		return p.Endpoints()[0].Address == healthy.URL
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, healthy.URL, p.Remote())
+	require.Equal(t, []string{healthy.URL, down}, p.Addresses())
+
+	require.NoError(t, p.Close())
+	require.NoError(t, p.Close())
+}
@@ -6,4 +6,7 @@ func (e _err) Error() string { return string(e) }
 
 const (
 	ErrTimeoutAfterWaitingForTxBroadcast _err = "timed out after waiting for tx to get included in the block"
+	ErrTxIndexingDisabled                _err = "node has tx indexing disabled"
+	// ErrNoTrustedHeader is returned by proven queries if the chain has no trusted header configured.
+	ErrNoTrustedHeader _err = "no trusted-height and trusted-hash configured to verify queries"
 )
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/base64"
+	"unicode"
+	"unicode/utf8"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// DecodeEvents returns events with readable attributes. Nodes running Tendermint
+// v0.34 base64 encode event attribute keys and values in their RPC responses,
+// while newer nodes return them as plain strings. If every attribute key of
+// events decodes to printable text, all keys and values are decoded, otherwise
+// events are returned unchanged.
+func DecodeEvents(events []abci.Event) []abci.Event {
+	if !base64Attributes(events) {
+		return events
+	}
+
+	decoded := make([]abci.Event, len(events))
+	for i, ev := range events {
+		attrs := make([]abci.EventAttribute, len(ev.Attributes))
+		for j, attr := range ev.Attributes {
+			attrs[j] = abci.EventAttribute{
+				Key:   decodeBase64String(attr.Key),
+				Value: decodeBase64String(attr.Value),
+				Index: attr.Index,
+			}
+		}
+		decoded[i] = abci.Event{Type: ev.Type, Attributes: attrs}
+	}
+	return decoded
+}
+
+// base64Attributes reports whether the attribute keys of events are base64 encoded.
+func base64Attributes(events []abci.Event) bool {
+	var keys int
+	for _, ev := range events {
+		for _, attr := range ev.Attributes {
+			bz, err := base64.StdEncoding.DecodeString(attr.Key)
+			if err != nil || !printable(bz) {
+				return false
+			}
+			keys++
+		}
+	}
+	return keys > 0
+}
+
+// decodeBase64String decodes s, returning it unchanged if it isn't base64.
+func decodeBase64String(s string) string {
+	bz, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return s
+	}
+	return string(bz)
+}
+
+func printable(bz []byte) bool {
+	if len(bz) == 0 || !utf8.Valid(bz) {
+		return false
+	}
+	for _, r := range string(bz) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
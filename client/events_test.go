@@ -0,0 +1,66 @@
+package client
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		events   []abci.Event
+		expected []abci.Event
+	}{
+		{
+			name: "base64 attributes are decoded",
+			events: []abci.Event{{
+				Type: "transfer",
+				Attributes: []abci.EventAttribute{
+					{Key: "cmVjaXBpZW50", Value: "Y29zbW9zMWFiYw=="},
+					{Key: "YW1vdW50", Value: ""},
+				},
+			}},
+			expected: []abci.Event{{
+				Type: "transfer",
+				Attributes: []abci.EventAttribute{
+					{Key: "recipient", Value: "cosmos1abc"},
+					{Key: "amount", Value: ""},
+				},
+			}},
+		},
+		{
+			name: "plain attributes are kept",
+			events: []abci.Event{{
+				Type: "transfer",
+				Attributes: []abci.EventAttribute{
+					{Key: "recipient", Value: "cosmos1abc"},
+					{Key: "amount", Value: "1uatom"},
+				},
+			}},
+			expected: []abci.Event{{
+				Type: "transfer",
+				Attributes: []abci.EventAttribute{
+					{Key: "recipient", Value: "cosmos1abc"},
+					{Key: "amount", Value: "1uatom"},
+				},
+			}},
+		},
+		{
+			name: "plain key that happens to be valid base64 is kept",
+			events: []abci.Event{{
+				Type:       "tx",
+				Attributes: []abci.EventAttribute{{Key: "hash", Value: "ABCD"}},
+			}},
+			expected: []abci.Event{{
+				Type:       "tx",
+				Attributes: []abci.EventAttribute{{Key: "hash", Value: "ABCD"}},
+			}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DecodeEvents(tt.events))
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoGRPCAddr is returned by GRPCConn if the chain has no gRPC address configured.
+var ErrNoGRPCAddr = errors.New("no gRPC address configured")
+
+// grpcConnState is the connection to the gRPC address of the config, dialed on first use.
+type grpcConnState struct {
+	mu   sync.Mutex
+	conn grpc.ClientConnInterface
+}
+
+// GRPCConn returns a connection to the gRPC server of the chain at GRPCAddr. Unlike the
+// ChainClient itself, which sends queries as ABCI queries over RPC, it queries the gRPC
+// server of a node directly. If GRPCAddrs has more addresses, calls go to the address
+// with the lowest latency and fail over to the next ones if it is unavailable.
+//
+// Addresses with an https:// or grpcs:// scheme, or a bare host:port with port 443, are
+// dialed with TLS. The connection is dialed on the first call and reused after that, until
+// the ChainClient is closed.
+func (cc *ChainClient) GRPCConn() (grpc.ClientConnInterface, error) {
+	cc.grpcConn.mu.Lock()
+	defer cc.grpcConn.mu.Unlock()
+
+	if cc.grpcConn.conn != nil {
+		return cc.grpcConn.conn, nil
+	}
+	addrs := cc.Config.grpcAddrs()
+	if len(addrs) == 0 {
+		return nil, ErrNoGRPCAddr
+	}
+	pool := &grpcPool{log: cc.log}
+	for _, addr := range addrs {
+		conn, err := cc.dialGRPC(addr)
+		if err != nil {
+			return nil, err
+		}
+		pool.endpoints = append(pool.endpoints, &grpcEndpoint{addr: addr, conn: conn})
+	}
+	if len(pool.endpoints) == 1 {
+		cc.grpcConn.conn = pool.endpoints[0].conn
+	} else {
+		cc.grpcConn.conn = pool
+	}
+	return cc.grpcConn.conn, nil
+}
+
+// closeGRPCConn closes the connection returned by GRPCConn, if it was dialed. The next call
+// to GRPCConn dials a new one.
+func (cc *ChainClient) closeGRPCConn() error {
+	cc.grpcConn.mu.Lock()
+	defer cc.grpcConn.mu.Unlock()
+
+	conn := cc.grpcConn.conn
+	cc.grpcConn.conn = nil
+	if closer, ok := conn.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (cc *ChainClient) dialGRPC(addr string) (*grpc.ClientConn, error) {
+	target, creds, err := grpcDialTarget(addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(target,
+		grpc.WithTransportCredentials(creds),
+		// The codec of the chain unpacks the interfaces of replies, like Invoke does
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec.NewProtoCodec(cc.Codec.InterfaceRegistry).GRPCCodec())),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial gRPC address %q: %w", addr, err)
+	}
+	return conn, nil
+}
+
+type grpcEndpoint struct {
+	addr   string
+	conn   grpc.ClientConnInterface
+	health endpointHealth
+}
+
+// grpcPool is a connection to several gRPC servers of a chain, calls go to the server with
+// the lowest latency and fail over to the next ones while the server is unavailable. Like
+// the endpoints of an EndpointPool, a server is left out for a while after failing calls.
+type grpcPool struct {
+	log       *zap.Logger
+	endpoints []*grpcEndpoint
+}
+
+var _ grpc.ClientConnInterface = &grpcPool{}
+
+// ranked returns the servers of the pool, the ones with an open circuit last, and the
+// others by latency with the servers never called last.
+func (p *grpcPool) ranked() []*grpcEndpoint {
+	now := time.Now()
+	out := append([]*grpcEndpoint(nil), p.endpoints...)
+	open := make(map[*grpcEndpoint]bool, len(out))
+	latency := make(map[*grpcEndpoint]time.Duration, len(out))
+	for _, e := range out {
+		open[e] = e.health.open(now)
+		e.health.mu.Lock()
+		latency[e] = e.health.latency
+		e.health.mu.Unlock()
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if open[a] != open[b] {
+			return open[b]
+		}
+		if (latency[a] == 0) != (latency[b] == 0) {
+			return latency[b] == 0
+		}
+		return latency[a] < latency[b]
+	})
+	return out
+}
+
+// Invoke implements the grpc ClientConn.Invoke method
+func (p *grpcPool) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	var lastErr error
+	for _, e := range p.ranked() {
+		start := time.Now()
+		err := e.conn.Invoke(ctx, method, args, reply, opts...)
+		if status.Code(err) != codes.Unavailable || ctx.Err() != nil {
+			if err == nil {
+				e.health.succeed()
+				e.health.mu.Lock()
+				e.health.latency = time.Since(start)
+				e.health.mu.Unlock()
+			}
+			return err
+		}
+		p.log.Debug("gRPC endpoint unavailable, failing over", zap.String("endpoint", e.addr), zap.Error(err))
+		e.health.fail(err, circuitFailures, circuitCooldown)
+		lastErr = err
+	}
+	return lastErr
+}
+
+// Close closes the connections to all the servers of the pool, it returns the first error.
+func (p *grpcPool) Close() error {
+	var firstErr error
+	for _, e := range p.endpoints {
+		if closer, ok := e.conn.(io.Closer); ok {
+			if err := closer.Close(); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", e.addr, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// NewStream implements the grpc ClientConn.NewStream method, streams are opened on the best server.
+func (p *grpcPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.ranked()[0].conn.NewStream(ctx, desc, method, opts...)
+}
+
+// grpcDialTarget returns the dial target and transport credentials of a gRPC address.
+func grpcDialTarget(addr string) (string, credentials.TransportCredentials, error) {
+	secure := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		// A bare host:port
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid gRPC address %q: %w", addr, err)
+		}
+		if port == "443" {
+			return addr, secure, nil
+		}
+		return addr, insecure.NewCredentials(), nil
+	}
+
+	switch u.Scheme {
+	case "https", "grpcs":
+		if u.Port() == "" {
+			return net.JoinHostPort(u.Hostname(), "443"), secure, nil
+		}
+		return u.Host, secure, nil
+	case "http", "grpc", "tcp":
+		if u.Port() == "" {
+			return net.JoinHostPort(u.Hostname(), "80"), insecure.NewCredentials(), nil
+		}
+		return u.Host, insecure.NewCredentials(), nil
+	default:
+		return "", nil, fmt.Errorf("invalid gRPC address %q: unsupported scheme %q", addr, u.Scheme)
+	}
+}
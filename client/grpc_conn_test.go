@@ -0,0 +1,55 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestGRPCDialTarget(t *testing.T) {
+	for _, tc := range []struct {
+		addr   string
+		target string
+		tls    bool
+	}{
+		{"https://grpc.cosmos.network", "grpc.cosmos.network:443", true},
+		{"https://grpc.cosmos.network:9443", "grpc.cosmos.network:9443", true},
+		{"grpcs://grpc.cosmos.network:9090", "grpc.cosmos.network:9090", true},
+		{"http://localhost:9090", "localhost:9090", false},
+		{"grpc.cosmos.network:443", "grpc.cosmos.network:443", true},
+		{"localhost:9090", "localhost:9090", false},
+		{"127.0.0.1:9090", "127.0.0.1:9090", false},
+	} {
+		target, creds, err := grpcDialTarget(tc.addr)
+		require.NoError(t, err, tc.addr)
+		require.Equal(t, tc.target, target, tc.addr)
+		require.Equal(t, tc.tls, creds.Info().SecurityProtocol == "tls", tc.addr)
+	}
+
+	_, _, err := grpcDialTarget("ws://localhost:9090")
+	require.Error(t, err)
+	_, _, err = grpcDialTarget("localhost")
+	require.Error(t, err)
+}
+
+func TestChainClientClose(t *testing.T) {
+	homepath := t.TempDir()
+	cfg := GetCosmosHubConfig(homepath, true)
+	cfg.GRPCAddr = "localhost:9090"
+	cl, err := NewChainClient(zaptest.NewLogger(t), cfg, homepath, nil, nil)
+	require.NoError(t, err)
+
+	conn, err := cl.GRPCConn()
+	require.NoError(t, err)
+	require.NoError(t, cl.Close())
+	require.Equal(t, connectivity.Shutdown, conn.(*grpc.ClientConn).GetState())
+
+	// The client dials a new connection after it was closed
+	again, err := cl.GRPCConn()
+	require.NoError(t, err)
+	require.NotSame(t, conn, again)
+	require.NoError(t, cl.Close())
+}
@@ -57,6 +57,10 @@ func (cc *ChainClient) Invoke(ctx context.Context, method string, req, reply int
 	// Case 2. Querying state.
 	inMd, _ := metadata.FromOutgoingContext(ctx)
 	abciRes, outMd, err := cc.RunGRPCQuery(ctx, method, req, inMd)
+	if height, _ := GetHeightFromMetadata(inMd); height > 0 {
+		// Fail rather than mix the state of several heights
+		err = CheckQueryHeight(height, outMd, err)
+	}
 	if err != nil {
 		return err
 	}
@@ -148,7 +152,17 @@ func (cc *ChainClient) TxServiceBroadcast(ctx context.Context, req *tx.Broadcast
 		return nil, status.Error(codes.InvalidArgument, "invalid empty tx")
 	}
 
-	resp, err := cc.BroadcastTx(ctx, req.TxBytes)
+	var opts []TxOption
+	switch req.Mode {
+	case tx.BroadcastMode_BROADCAST_MODE_ASYNC:
+		opts = append(opts, WithBroadcastMode(BroadcastModeAsync))
+	case tx.BroadcastMode_BROADCAST_MODE_SYNC:
+		opts = append(opts, WithBroadcastMode(BroadcastModeSync))
+	case tx.BroadcastMode_BROADCAST_MODE_BLOCK:
+		opts = append(opts, WithBroadcastMode(BroadcastModeCommit))
+	}
+
+	resp, err := cc.BroadcastTx(ctx, req.TxBytes, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/cosmos/ibc-go/v7/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+)
+
+// IBCTimeout is the height and timestamp on the counterparty chain after which an IBC
+// packet times out. A zero Height or Timestamp disables that timeout.
+// TransferTimeout derives one from the client tracking the counterparty chain.
+type IBCTimeout struct {
+	Height    clienttypes.Height
+	Timestamp uint64 // unix nanoseconds
+}
+
+// IBCTransferOptions are the options of the transfer built by NewIBCTransfer.
+type IBCTransferOptions struct {
+	// TimeoutHeightOffset and TimeoutTimeOffset are the number of blocks and the time after
+	// the latest counterparty height and time known to the client of the channel after
+	// which the transfer times out. A zero offset disables that timeout.
+	TimeoutHeightOffset uint64
+	TimeoutTimeOffset   time.Duration
+	// Timeout overrides the timeout derived from the offsets when it is set.
+	Timeout *IBCTimeout
+	// PacketMemo is the memo of the transfer packet, read by the counterparty chain.
+	PacketMemo string
+	// CounterpartyPrefixes are the bech32 account prefixes of chains by chain id. The
+	// receiver must have the prefix of the counterparty chain if it is one of them.
+	CounterpartyPrefixes map[string]string
+}
+
+// NewMsgTransfer returns the msg to transfer amount from sender over the transfer port
+// of sourceChannel to receiver on the counterparty chain.
+func (cc *ChainClient) NewMsgTransfer(sender sdk.AccAddress, sourceChannel, receiver string, amount sdk.Coin, timeout IBCTimeout) (*transfertypes.MsgTransfer, error) {
+	if timeout.Height.IsZero() && timeout.Timestamp == 0 {
+		return nil, fmt.Errorf("a timeout height or timestamp is required")
+	}
+	msg := &transfertypes.MsgTransfer{
+		SourcePort:       transfertypes.PortID,
+		SourceChannel:    sourceChannel,
+		Token:            amount,
+		Sender:           cc.MustEncodeAccAddr(sender),
+		Receiver:         receiver,
+		TimeoutHeight:    timeout.Height,
+		TimeoutTimestamp: timeout.Timestamp,
+	}
+
+	done := cc.SetSDKContext()
+	defer done()
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// NewIBCTransfer returns the msg to transfer amount from sender over the transfer port of
+// sourceChannel to receiver on the counterparty chain. The client state tracking the
+// counterparty chain gives the timeout of the transfer, unless opts overrides it, and the
+// chain id to check the receiver against opts.CounterpartyPrefixes.
+func (cc *ChainClient) NewIBCTransfer(ctx context.Context, sender sdk.AccAddress, sourceChannel, receiver string, amount sdk.Coin, opts IBCTransferOptions) (*transfertypes.MsgTransfer, error) {
+	if opts.Timeout == nil && opts.TimeoutHeightOffset == 0 && opts.TimeoutTimeOffset == 0 {
+		return nil, fmt.Errorf("a timeout height or time offset is required")
+	}
+	var (
+		clientId    string
+		clientState exported.ClientState
+		err         error
+	)
+	if opts.Timeout == nil || len(opts.CounterpartyPrefixes) > 0 {
+		if clientId, clientState, err = CounterpartyClientState(ctx, cc, transfertypes.PortID, sourceChannel); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateCounterpartyAddress(clientState, opts.CounterpartyPrefixes, receiver); err != nil {
+		return nil, err
+	}
+
+	timeout := opts.Timeout
+	if timeout == nil {
+		derived, err := transferTimeout(ctx, cc, clientId, clientState, opts.TimeoutHeightOffset, opts.TimeoutTimeOffset)
+		if err != nil {
+			return nil, err
+		}
+		timeout = &derived
+	}
+	msg, err := cc.NewMsgTransfer(sender, sourceChannel, receiver, amount, *timeout)
+	if err != nil {
+		return nil, err
+	}
+	msg.Memo = opts.PacketMemo
+	return msg, nil
+}
+
+// SendIBCTransfer transfers amount from the configured key over the transfer port of
+// sourceChannel to receiver on the counterparty chain, see NewIBCTransfer.
+func (cc *ChainClient) SendIBCTransfer(ctx context.Context, sourceChannel, receiver string, amount sdk.Coin, transferOpts IBCTransferOptions, memo string, opts ...TxOption) (*sdk.TxResponse, error) {
+	sender, err := cc.GetKeyAddress()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := cc.NewIBCTransfer(ctx, sender, sourceChannel, receiver, amount, transferOpts)
+	if err != nil {
+		return nil, err
+	}
+	return cc.SendMsg(ctx, msg, memo, opts...)
+}
+
+// validateCounterpartyAddress checks that addr has the bech32 prefix in prefixes of the
+// counterparty chain tracked by clientState, if it has one.
+func validateCounterpartyAddress(clientState exported.ClientState, prefixes map[string]string, addr string) error {
+	tmClientState, ok := clientState.(*ibctm.ClientState)
+	if !ok {
+		return nil
+	}
+	prefix, ok := prefixes[tmClientState.ChainId]
+	if !ok {
+		return nil
+	}
+	if _, err := sdk.GetFromBech32(addr, prefix); err != nil {
+		return fmt.Errorf("invalid receiver %s for %s: %w", addr, tmClientState.ChainId, err)
+	}
+	return nil
+}
+
+// CounterpartyClientState returns the id and state of the client tracking the counterparty
+// chain of the channel on portId, queried over conn.
+func CounterpartyClientState(ctx context.Context, conn gogogrpc.ClientConn, portId, channelId string) (string, exported.ClientState, error) {
+	channel, err := channeltypes.NewQueryClient(conn).Channel(ctx, &channeltypes.QueryChannelRequest{PortId: portId, ChannelId: channelId})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(channel.Channel.ConnectionHops) == 0 {
+		return "", nil, fmt.Errorf("channel %s has no connection", channelId)
+	}
+	connection, err := connectiontypes.NewQueryClient(conn).Connection(ctx, &connectiontypes.QueryConnectionRequest{ConnectionId: channel.Channel.ConnectionHops[0]})
+	if err != nil {
+		return "", nil, err
+	}
+	clientId := connection.Connection.ClientId
+	res, err := clienttypes.NewQueryClient(conn).ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientId})
+	if err != nil {
+		return "", nil, err
+	}
+	clientState, err := clienttypes.UnpackClientState(res.ClientState)
+	if err != nil {
+		return "", nil, err
+	}
+	return clientId, clientState, nil
+}
+
+// TransferTimeout returns the timeout of a packet sent over the channel on portId,
+// heightOffset blocks and timeOffset after the latest counterparty height and time known
+// to the chain of conn. A zero offset disables that timeout.
+func TransferTimeout(ctx context.Context, conn gogogrpc.ClientConn, portId, channelId string, heightOffset uint64, timeOffset time.Duration) (IBCTimeout, error) {
+	if heightOffset == 0 && timeOffset == 0 {
+		return IBCTimeout{}, fmt.Errorf("a timeout height or time offset is required")
+	}
+	clientId, clientState, err := CounterpartyClientState(ctx, conn, portId, channelId)
+	if err != nil {
+		return IBCTimeout{}, err
+	}
+	return transferTimeout(ctx, conn, clientId, clientState, heightOffset, timeOffset)
+}
+
+// transferTimeout returns the timeout heightOffset blocks and timeOffset after the latest
+// height and time of the client clientId with clientState.
+func transferTimeout(ctx context.Context, conn gogogrpc.ClientConn, clientId string, clientState exported.ClientState, heightOffset uint64, timeOffset time.Duration) (IBCTimeout, error) {
+	var timeout IBCTimeout
+	latest, ok := clientState.GetLatestHeight().(clienttypes.Height)
+	if !ok {
+		return timeout, fmt.Errorf("unexpected height type %T of client %s", clientState.GetLatestHeight(), clientId)
+	}
+
+	if heightOffset != 0 {
+		timeout.Height = clienttypes.NewHeight(latest.RevisionNumber, latest.RevisionHeight+heightOffset)
+	}
+	if timeOffset != 0 {
+		res, err := clienttypes.NewQueryClient(conn).ConsensusState(ctx, &clienttypes.QueryConsensusStateRequest{
+			ClientId:       clientId,
+			RevisionNumber: latest.RevisionNumber,
+			RevisionHeight: latest.RevisionHeight,
+		})
+		if err != nil {
+			return timeout, err
+		}
+		consensusState, err := clienttypes.UnpackConsensusState(res.ConsensusState)
+		if err != nil {
+			return timeout, err
+		}
+		timeout.Timestamp = consensusState.GetTimestamp() + uint64(timeOffset.Nanoseconds())
+	}
+	return timeout, nil
+}
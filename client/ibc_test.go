@@ -0,0 +1,123 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/gogoproto/proto"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v7/modules/core/23-commitment/types"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNewMsgTransfer(t *testing.T) {
+	homepath := t.TempDir()
+	cl, err := client.NewChainClient(
+		zaptest.NewLogger(t),
+		client.GetCosmosHubConfig(homepath, true),
+		homepath, nil, nil,
+	)
+	require.NoError(t, err)
+
+	key, err := cl.AddKey("default", sdk.CoinType)
+	require.NoError(t, err)
+	sender, err := cl.DecodeBech32AccAddr(key.Address)
+	require.NoError(t, err)
+	amount := sdk.NewInt64Coin("uatom", 100)
+	receiver := "osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5wf3z6e"
+
+	_, err = cl.NewMsgTransfer(sender, "channel-141", receiver, amount, client.IBCTimeout{})
+	require.ErrorContains(t, err, "timeout")
+
+	timeout := client.IBCTimeout{Height: clienttypes.NewHeight(1, 1000), Timestamp: 1700000000000000000}
+	msg, err := cl.NewMsgTransfer(sender, "channel-141", receiver, amount, timeout)
+	require.NoError(t, err)
+	require.Equal(t, "transfer", msg.SourcePort)
+	require.Equal(t, key.Address, msg.Sender)
+	require.Equal(t, timeout.Height, msg.TimeoutHeight)
+	require.Equal(t, timeout.Timestamp, msg.TimeoutTimestamp)
+
+	_, err = cl.NewMsgTransfer(sender, "not a channel", receiver, amount, timeout)
+	require.Error(t, err)
+}
+
+// mockRPCQuery makes mc answer the ABCI queries of the gRPC method path with res.
+func mockRPCQuery(t *testing.T, mc *mocks.Client, path string, res proto.Message) {
+	bz, err := proto.Marshal(res)
+	require.NoError(t, err)
+	mc.On("ABCIQueryWithOptions", mock.Anything, path, mock.Anything, mock.Anything).
+		Return(&coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: 100}}, nil)
+}
+
+func TestNewIBCTransfer(t *testing.T) {
+	homepath := t.TempDir()
+	cfg := client.GetCosmosHubConfig(homepath, true)
+	cfg.Modules = client.ModuleBasics
+	cl, err := client.NewChainClient(zaptest.NewLogger(t), cfg, homepath, nil, nil)
+	require.NoError(t, err)
+	mc := new(mocks.Client)
+	cl.RPCClient = mc
+
+	latest := clienttypes.NewHeight(1, 500)
+	clientState, err := codectypes.NewAnyWithValue(&ibctm.ClientState{ChainId: "osmosis-1", LatestHeight: latest})
+	require.NoError(t, err)
+	consensusTime := time.Unix(1700000000, 0)
+	consensusState, err := codectypes.NewAnyWithValue(&ibctm.ConsensusState{
+		Timestamp: consensusTime,
+		Root:      commitmenttypes.NewMerkleRoot([]byte("root")),
+	})
+	require.NoError(t, err)
+	mockRPCQuery(t, mc, "/ibc.core.channel.v1.Query/Channel", &channeltypes.QueryChannelResponse{
+		Channel: &channeltypes.Channel{ConnectionHops: []string{"connection-0"}},
+	})
+	mockRPCQuery(t, mc, "/ibc.core.connection.v1.Query/Connection", &connectiontypes.QueryConnectionResponse{
+		Connection: &connectiontypes.ConnectionEnd{ClientId: "07-tendermint-0"},
+	})
+	mockRPCQuery(t, mc, "/ibc.core.client.v1.Query/ClientState", &clienttypes.QueryClientStateResponse{ClientState: clientState})
+	mockRPCQuery(t, mc, "/ibc.core.client.v1.Query/ConsensusState", &clienttypes.QueryConsensusStateResponse{ConsensusState: consensusState})
+
+	key, err := cl.AddKey("default", sdk.CoinType)
+	require.NoError(t, err)
+	sender, err := cl.DecodeBech32AccAddr(key.Address)
+	require.NoError(t, err)
+	amount := sdk.NewInt64Coin("uatom", 100)
+	receiver, err := bech32.ConvertAndEncode("osmo", sender)
+	require.NoError(t, err)
+	opts := client.IBCTransferOptions{
+		TimeoutHeightOffset:  1000,
+		TimeoutTimeOffset:    10 * time.Minute,
+		PacketMemo:           "packet memo",
+		CounterpartyPrefixes: map[string]string{"osmosis-1": "osmo"},
+	}
+
+	msg, err := cl.NewIBCTransfer(context.Background(), sender, "channel-141", receiver, amount, opts)
+	require.NoError(t, err)
+	require.Equal(t, clienttypes.NewHeight(1, 1500), msg.TimeoutHeight)
+	require.Equal(t, uint64(consensusTime.Add(10*time.Minute).UnixNano()), msg.TimeoutTimestamp)
+	require.Equal(t, "packet memo", msg.Memo)
+	mc.AssertNumberOfCalls(t, "ABCIQueryWithOptions", 4)
+
+	_, err = cl.NewIBCTransfer(context.Background(), sender, "channel-141", key.Address, amount, opts)
+	require.ErrorContains(t, err, "invalid receiver")
+
+	// An explicit timeout overrides the offsets
+	timeout := client.IBCTimeout{Height: clienttypes.NewHeight(1, 1000)}
+	opts.Timeout = &timeout
+	msg, err = cl.NewIBCTransfer(context.Background(), sender, "channel-141", receiver, amount, opts)
+	require.NoError(t, err)
+	require.Equal(t, timeout.Height, msg.TimeoutHeight)
+	require.Zero(t, msg.TimeoutTimestamp)
+}
@@ -0,0 +1,294 @@
+// Package indexer walks the blocks of a chain and indexes their txs, messages, events and
+// attributes into a SQLite database.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/lens/client"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultWorkers is the number of blocks fetched at the same time by default.
+	DefaultWorkers = 4
+	// DefaultPollInterval is how often the indexer looks for new blocks when following the chain.
+	DefaultPollInterval = 5 * time.Second
+)
+
+// Block is a block decoded for the index.
+type Block struct {
+	Height   int64
+	Hash     string
+	Time     time.Time
+	Proposer string
+	Txs      []Tx
+
+	BeginBlockEvents []abci.Event
+	EndBlockEvents   []abci.Event
+}
+
+// Tx is a tx of a block with its result. Memo, Fee and Messages are empty when the tx
+// can't be decoded with the codec of the chain.
+type Tx struct {
+	Index     uint32
+	Hash      string
+	Code      uint32
+	Codespace string
+	GasWanted int64
+	GasUsed   int64
+	Memo      string
+	Fee       string
+	Log       string
+	Messages  []Message
+	Events    []abci.Event
+}
+
+// Message is a message of a tx, encoded as JSON with the codec of the chain.
+type Message struct {
+	TypeURL string
+	JSON    []byte
+}
+
+// Options are the options of an Indexer.
+type Options struct {
+	// StartHeight is the first height to index, when the store has no block after it.
+	// The earliest height of the node is used when it is 0 and the store is empty.
+	StartHeight int64
+	// EndHeight is the last height to index, the latest height of the chain if 0.
+	EndHeight int64
+	// Workers is the number of blocks fetched at the same time, DefaultWorkers if 0.
+	Workers int
+	// Follow keeps indexing the new blocks of the chain once the latest height is reached.
+	Follow bool
+	// PollInterval is how often new blocks are looked for with Follow, DefaultPollInterval if 0.
+	PollInterval time.Duration
+}
+
+// Indexer indexes the blocks of a chain into a Store. Blocks are fetched concurrently and
+// committed in order of height, so an interrupted indexer resumes after the last block
+// of the store.
+type Indexer struct {
+	cc    *client.ChainClient
+	store *Store
+	opts  Options
+	log   *zap.Logger
+}
+
+// New returns an indexer of the blocks of the chain of cc into store.
+func New(log *zap.Logger, cc *client.ChainClient, store *Store, opts Options) *Indexer {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	return &Indexer{cc: cc, store: store, opts: opts, log: log}
+}
+
+// Run indexes the blocks from the start height, or from the block after the last one of
+// the store, up to the end height. With Follow it then indexes the new blocks until ctx is
+// done.
+func (ix *Indexer) Run(ctx context.Context) error {
+	last, err := ix.store.LastHeight(ctx)
+	if err != nil {
+		return err
+	}
+	next := last + 1
+	if ix.opts.StartHeight > next {
+		next = ix.opts.StartHeight
+	}
+	if last == 0 && ix.opts.StartHeight == 0 {
+		status, err := ix.cc.RPCClient.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if status.SyncInfo.EarliestBlockHeight > next {
+			next = status.SyncInfo.EarliestBlockHeight
+		}
+	}
+	if last > 0 {
+		ix.log.Info("Resuming index", zap.Int64("last_height", last), zap.Int64("next_height", next))
+	}
+
+	for {
+		status, err := ix.cc.RPCClient.Status(ctx)
+		if err != nil {
+			return err
+		}
+		end := status.SyncInfo.LatestBlockHeight
+		if ix.opts.EndHeight > 0 && ix.opts.EndHeight < end {
+			end = ix.opts.EndHeight
+		}
+		if next <= end {
+			if err := ix.indexRange(ctx, next, end); err != nil {
+				return err
+			}
+			ix.log.Info("Indexed blocks", zap.Int64("from", next), zap.Int64("to", end))
+			next = end + 1
+		}
+
+		if !ix.opts.Follow || (ix.opts.EndHeight > 0 && next > ix.opts.EndHeight) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(ix.opts.PollInterval):
+		}
+	}
+}
+
+// indexRange indexes the blocks from start to end. Workers fetch the blocks at the same
+// time, and they are committed as soon as all the blocks before them are. The blocks
+// fetched ahead of the next one to commit are limited to twice the number of workers.
+func (ix *Indexer) indexRange(ctx context.Context, start, end int64) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	heights := make(chan int64)
+	fetched := make(chan *Block)
+	ahead := make(chan struct{}, 2*ix.opts.Workers)
+
+	eg.Go(func() error {
+		defer close(heights)
+		for h := start; h <= end; h++ {
+			select {
+			case ahead <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			select {
+			case heights <- h:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+
+	var workers sync.WaitGroup
+	for i := 0; i < ix.opts.Workers; i++ {
+		workers.Add(1)
+		eg.Go(func() error {
+			defer workers.Done()
+			for h := range heights {
+				b, err := ix.FetchBlock(ctx, h)
+				if err != nil {
+					return fmt.Errorf("failed to fetch block %d: %w", h, err)
+				}
+				select {
+				case fetched <- b:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		})
+	}
+	go func() {
+		workers.Wait()
+		close(fetched)
+	}()
+
+	eg.Go(func() error {
+		pending := make(map[int64]*Block)
+		next := start
+		for b := range fetched {
+			pending[b.Height] = b
+			for {
+				b, ok := pending[next]
+				if !ok {
+					break
+				}
+				if err := ix.store.WriteBlock(ctx, b); err != nil {
+					return err
+				}
+				delete(pending, next)
+				next++
+				<-ahead
+			}
+		}
+		if next <= end && ctx.Err() == nil {
+			return errors.New("not all blocks were fetched")
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+// FetchBlock fetches the block at height with its results, and decodes its txs.
+func (ix *Indexer) FetchBlock(ctx context.Context, height int64) (*Block, error) {
+	block, err := ix.cc.RPCClient.Block(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	results, err := ix.cc.RPCClient.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.TxsResults) != len(block.Block.Txs) {
+		return nil, fmt.Errorf("block has %d txs but %d tx results", len(block.Block.Txs), len(results.TxsResults))
+	}
+
+	b := &Block{
+		Height:           height,
+		Hash:             block.BlockID.Hash.String(),
+		Time:             block.Block.Time,
+		Proposer:         block.Block.ProposerAddress.String(),
+		BeginBlockEvents: client.DecodeEvents(results.BeginBlockEvents),
+		EndBlockEvents:   client.DecodeEvents(results.EndBlockEvents),
+	}
+	for i, txBz := range block.Block.Txs {
+		res := results.TxsResults[i]
+		tx := Tx{
+			Index:     uint32(i),
+			Hash:      fmt.Sprintf("%X", tmtypes.Tx(txBz).Hash()),
+			Code:      res.Code,
+			Codespace: res.Codespace,
+			GasWanted: res.GasWanted,
+			GasUsed:   res.GasUsed,
+			Log:       res.Log,
+			Events:    client.DecodeEvents(res.Events),
+		}
+		if err := ix.decodeTx(txBz, &tx); err != nil {
+			// Index the result of the tx even if the codec of the chain can't decode it
+			ix.log.Warn("Failed to decode tx", zap.Int64("height", height), zap.String("hash", tx.Hash), zap.Error(err))
+		}
+		b.Txs = append(b.Txs, tx)
+	}
+	return b, nil
+}
+
+// decodeTx sets the memo, fee and messages of tx from the bytes of the tx.
+func (ix *Indexer) decodeTx(txBz []byte, tx *Tx) error {
+	decoded, err := ix.cc.Codec.TxConfig.TxDecoder()(txBz)
+	if err != nil {
+		return err
+	}
+	var msgs []Message
+	for _, msg := range decoded.GetMsgs() {
+		bz, err := ix.cc.Codec.Marshaler.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, Message{TypeURL: sdk.MsgTypeURL(msg), JSON: bz})
+	}
+	tx.Messages = msgs
+	if memoTx, ok := decoded.(sdk.TxWithMemo); ok {
+		tx.Memo = memoTx.GetMemo()
+	}
+	if feeTx, ok := decoded.(sdk.FeeTx); ok {
+		tx.Fee = feeTx.GetFee().String()
+	}
+	return nil
+}
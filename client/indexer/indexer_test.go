@@ -0,0 +1,121 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// chainClient serves blocks up to latest, block 2 has a bank send and an undecodable tx.
+type chainClient struct {
+	rpcclient.Client
+	latest int64
+	sendTx []byte
+}
+
+func (c chainClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	res := &coretypes.ResultStatus{}
+	res.SyncInfo.EarliestBlockHeight = 1
+	res.SyncInfo.LatestBlockHeight = c.latest
+	return res, nil
+}
+
+func (c chainClient) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	// Earlier blocks take longer, so they are fetched after the later ones
+	time.Sleep(time.Duration(c.latest-*height) * 10 * time.Millisecond)
+	block := &tmtypes.Block{Header: tmtypes.Header{Height: *height, Time: time.Unix(*height, 0)}}
+	if *height == 2 {
+		block.Txs = tmtypes.Txs{c.sendTx, []byte("not a tx")}
+	}
+	return &coretypes.ResultBlock{Block: block}, nil
+}
+
+func (c chainClient) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	res := &coretypes.ResultBlockResults{
+		Height: *height,
+		BeginBlockEvents: []abci.Event{{Type: "mint", Attributes: []abci.EventAttribute{
+			{Key: "amount", Value: "100uatom"},
+		}}},
+	}
+	if *height == 2 {
+		res.TxsResults = []*abci.ResponseDeliverTx{
+			{GasUsed: 80000, Events: []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: "recipient", Value: "cosmos1recipient"},
+				{Key: "amount", Value: "5uatom"},
+			}}}},
+			{Code: 2, Codespace: "sdk", Log: "tx parse error"},
+		}
+	}
+	return res, nil
+}
+
+func TestIndexerResumes(t *testing.T) {
+	homepath := t.TempDir()
+	cfg := client.GetCosmosHubConfig(homepath, true)
+	cfg.Modules = client.ModuleBasics
+	cl, err := client.NewChainClient(zaptest.NewLogger(t), cfg, homepath, nil, nil)
+	require.NoError(t, err)
+
+	builder := cl.Codec.TxConfig.NewTxBuilder()
+	from, to := sdk.AccAddress("from________________"), sdk.AccAddress("to__________________")
+	require.NoError(t, builder.SetMsgs(bankTypes.NewMsgSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("uatom", 5)))))
+	builder.SetMemo("rent")
+	builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("uatom", 2000)))
+	sendTx, err := cl.Codec.TxConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	store, err := OpenStore(filepath.Join(t.TempDir(), "index.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+	cl.RPCClient = chainClient{latest: 3, sendTx: sendTx}
+	require.NoError(t, New(zaptest.NewLogger(t), cl, store, Options{Workers: 3}).Run(ctx))
+	last, err := store.LastHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), last)
+
+	// The second run indexes the new blocks only, indexing a block twice would fail
+	cl.RPCClient = chainClient{latest: 6, sendTx: sendTx}
+	require.NoError(t, New(zaptest.NewLogger(t), cl, store, Options{Workers: 3}).Run(ctx))
+	last, err = store.LastHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), last)
+
+	db := store.DB()
+	count := func(query string, args ...interface{}) (n int) {
+		require.NoError(t, db.QueryRow(query, args...).Scan(&n))
+		return n
+	}
+	require.Equal(t, 6, count("SELECT COUNT(*) FROM blocks"))
+	require.Equal(t, 6, count("SELECT COUNT(*) FROM events WHERE source = ?", SourceBeginBlock))
+	require.Equal(t, 2, count("SELECT COUNT(*) FROM txs WHERE height = 2"))
+
+	var memo, fee, typeURL string
+	require.NoError(t, db.QueryRow(
+		"SELECT t.memo, t.fee, m.type_url FROM txs t JOIN messages m ON m.height = t.height AND m.tx_index = t.tx_index WHERE t.hash = ?",
+		fmt.Sprintf("%X", tmtypes.Tx(sendTx).Hash()),
+	).Scan(&memo, &fee, &typeURL))
+	require.Equal(t, "rent", memo)
+	require.Equal(t, "2000uatom", fee)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", typeURL)
+
+	// The undecodable tx is indexed with its result
+	require.Equal(t, 0, count("SELECT COUNT(*) FROM messages WHERE tx_index = 1"))
+	require.Equal(t, 1, count("SELECT COUNT(*) FROM txs WHERE tx_index = 1 AND code = 2"))
+
+	require.Equal(t, 1, count(`SELECT COUNT(*) FROM attributes a JOIN events e ON e.id = a.event_id
+		WHERE e.source = ? AND e.tx_index = 0 AND a.key = 'recipient' AND a.value = 'cosmos1recipient'`, SourceTx))
+}
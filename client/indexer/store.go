@@ -0,0 +1,189 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	_ "github.com/mattn/go-sqlite3" // registers the sqlite3 driver
+)
+
+// schema creates the tables of the index. Events belong to a tx when tx_index is set,
+// otherwise to the begin or end block of their height, as told by source.
+const schema = `
+CREATE TABLE IF NOT EXISTS blocks (
+	height   INTEGER PRIMARY KEY,
+	hash     TEXT NOT NULL,
+	time     TEXT NOT NULL,
+	proposer TEXT NOT NULL,
+	num_txs  INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS txs (
+	height     INTEGER NOT NULL,
+	tx_index   INTEGER NOT NULL,
+	hash       TEXT NOT NULL,
+	code       INTEGER NOT NULL,
+	codespace  TEXT NOT NULL,
+	gas_wanted INTEGER NOT NULL,
+	gas_used   INTEGER NOT NULL,
+	memo       TEXT NOT NULL,
+	fee        TEXT NOT NULL,
+	log        TEXT NOT NULL,
+	PRIMARY KEY (height, tx_index)
+);
+CREATE INDEX IF NOT EXISTS txs_hash ON txs (hash);
+
+CREATE TABLE IF NOT EXISTS messages (
+	height    INTEGER NOT NULL,
+	tx_index  INTEGER NOT NULL,
+	msg_index INTEGER NOT NULL,
+	type_url  TEXT NOT NULL,
+	json      TEXT NOT NULL,
+	PRIMARY KEY (height, tx_index, msg_index)
+);
+CREATE INDEX IF NOT EXISTS messages_type_url ON messages (type_url);
+
+CREATE TABLE IF NOT EXISTS events (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	height      INTEGER NOT NULL,
+	tx_index    INTEGER,
+	source      TEXT NOT NULL,
+	event_index INTEGER NOT NULL,
+	type        TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS events_height ON events (height, tx_index);
+CREATE INDEX IF NOT EXISTS events_type ON events (type);
+
+CREATE TABLE IF NOT EXISTS attributes (
+	event_id   INTEGER NOT NULL REFERENCES events (id),
+	attr_index INTEGER NOT NULL,
+	key        TEXT NOT NULL,
+	value      TEXT NOT NULL,
+	PRIMARY KEY (event_id, attr_index)
+);
+CREATE INDEX IF NOT EXISTS attributes_key_value ON attributes (key, value);
+`
+
+// Event sources of the events table.
+const (
+	SourceBeginBlock = "begin_block"
+	SourceTx         = "tx"
+	SourceEndBlock   = "end_block"
+)
+
+// Store is an index of blocks, txs, messages, events and attributes in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens the SQLite database at path, creating it and its tables if needed.
+func OpenStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=5000", path))
+	if err != nil {
+		return nil, err
+	}
+	// Blocks are committed one at a time, a single connection avoids lock contention
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create the tables of %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// DB returns the database of the store, to query the index.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// LastHeight returns the height of the last block indexed, 0 if the store is empty.
+func (s *Store) LastHeight(ctx context.Context) (int64, error) {
+	var height sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, "SELECT MAX(height) FROM blocks").Scan(&height); err != nil {
+		return 0, err
+	}
+	return height.Int64, nil
+}
+
+// WriteBlock writes a block with its txs, messages and events in a single transaction, so
+// a block is either indexed entirely or not at all.
+func (s *Store) WriteBlock(ctx context.Context, b *Block) (err error) {
+	dbTx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = dbTx.Rollback()
+		}
+	}()
+
+	if err := writeEvents(ctx, dbTx, b.Height, nil, SourceBeginBlock, b.BeginBlockEvents); err != nil {
+		return err
+	}
+	for _, tx := range b.Txs {
+		if _, err := dbTx.ExecContext(ctx,
+			"INSERT INTO txs (height, tx_index, hash, code, codespace, gas_wanted, gas_used, memo, fee, log) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			b.Height, tx.Index, tx.Hash, tx.Code, tx.Codespace, tx.GasWanted, tx.GasUsed, tx.Memo, tx.Fee, tx.Log,
+		); err != nil {
+			return fmt.Errorf("failed to write tx %s: %w", tx.Hash, err)
+		}
+		for i, msg := range tx.Messages {
+			if _, err := dbTx.ExecContext(ctx,
+				"INSERT INTO messages (height, tx_index, msg_index, type_url, json) VALUES (?, ?, ?, ?, ?)",
+				b.Height, tx.Index, i, msg.TypeURL, string(msg.JSON),
+			); err != nil {
+				return fmt.Errorf("failed to write message %d of tx %s: %w", i, tx.Hash, err)
+			}
+		}
+		index := tx.Index
+		if err := writeEvents(ctx, dbTx, b.Height, &index, SourceTx, tx.Events); err != nil {
+			return err
+		}
+	}
+	if err := writeEvents(ctx, dbTx, b.Height, nil, SourceEndBlock, b.EndBlockEvents); err != nil {
+		return err
+	}
+
+	// The block goes last, LastHeight only sees blocks with everything else written
+	if _, err := dbTx.ExecContext(ctx,
+		"INSERT INTO blocks (height, hash, time, proposer, num_txs) VALUES (?, ?, ?, ?, ?)",
+		b.Height, b.Hash, b.Time.UTC().Format(time.RFC3339Nano), b.Proposer, len(b.Txs),
+	); err != nil {
+		return fmt.Errorf("failed to write block %d: %w", b.Height, err)
+	}
+	return dbTx.Commit()
+}
+
+func writeEvents(ctx context.Context, dbTx *sql.Tx, height int64, txIndex *uint32, source string, events []abci.Event) error {
+	for i, ev := range events {
+		res, err := dbTx.ExecContext(ctx,
+			"INSERT INTO events (height, tx_index, source, event_index, type) VALUES (?, ?, ?, ?, ?)",
+			height, txIndex, source, i, ev.Type,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to write %s event %d of block %d: %w", source, i, height, err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		for j, attr := range ev.Attributes {
+			if _, err := dbTx.ExecContext(ctx,
+				"INSERT INTO attributes (event_id, attr_index, key, value) VALUES (?, ?, ?, ?)",
+				id, j, attr.Key, attr.Value,
+			); err != nil {
+				return fmt.Errorf("failed to write attribute %d of %s event %d of block %d: %w", j, source, i, height, err)
+			}
+		}
+	}
+	return nil
+}
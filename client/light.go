@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/light"
+	provtypes "github.com/cometbft/cometbft/light/provider"
+	prov "github.com/cometbft/cometbft/light/provider/http"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"go.uber.org/zap"
+)
+
+// lightClientState is the light client verifying proven queries, created on first use.
+type lightClientState struct {
+	mu     sync.Mutex
+	client *light.Client
+}
+
+// lightClient returns the light client of the chain, it trusts the header of the config and
+// uses the LightProvider as primary. Headers are cross-checked with the providers of the
+// light witnesses of the config. Without witnesses the primary is its own witness, which
+// can't detect a primary serving a fork, so a warning is logged.
+func (cc *ChainClient) lightClient(ctx context.Context) (*light.Client, error) {
+	cc.light.mu.Lock()
+	defer cc.light.mu.Unlock()
+
+	if cc.light.client != nil {
+		return cc.light.client, nil
+	}
+	trustOptions, err := cc.Config.TrustOptions()
+	if err != nil {
+		return nil, err
+	}
+	witnesses := []provtypes.Provider{cc.LightProvider}
+	if len(cc.Config.LightWitnesses) == 0 {
+		cc.log.Warn(
+			"No light witnesses configured, headers are only verified against the primary, configure light-witnesses to detect a primary serving a fork",
+			zap.String("chain_id", cc.Config.ChainID),
+		)
+	} else {
+		witnesses = witnesses[:0]
+		for _, addr := range cc.Config.LightWitnesses {
+			witness, err := prov.New(cc.Config.ChainID, addr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid light witness %s: %w", addr, err)
+			}
+			witnesses = append(witnesses, witness)
+		}
+	}
+	client, err := light.NewClient(ctx, cc.Config.ChainID, trustOptions, cc.LightProvider, witnesses,
+		lightdb.New(dbm.NewMemDB(), cc.Config.ChainID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create light client: %w", err)
+	}
+	cc.light.client = client
+	return client, nil
+}
+
+// VerifiedLightBlock returns the light block at height, verified by the light client from
+// the trusted header of the config.
+func (cc *ChainClient) VerifiedLightBlock(ctx context.Context, height int64) (*tmtypes.LightBlock, error) {
+	lc, err := cc.lightClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lc.VerifyLightBlockAtHeight(ctx, height, time.Now())
+}
+
+// verifyQueryProof checks the merkle proof of the response to a store query on path
+// against the app hash of a verified header.
+func (cc *ChainClient) verifyQueryProof(ctx context.Context, path string, res abci.ResponseQuery) error {
+	if res.ProofOps == nil {
+		return errors.New("the query response has no proof")
+	}
+	// The app hash of the state at a height is in the header of the next height
+	lb, err := cc.VerifiedLightBlock(ctx, res.Height+1)
+	if err != nil {
+		return fmt.Errorf("failed to verify the header of height %d: %w", res.Height+1, err)
+	}
+
+	// path is /store/<storeName>/key, checked by isQueryStoreWithProof
+	storeName := strings.SplitN(path[1:], "/", 3)[1]
+	kp := merkle.KeyPath{}.
+		AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+		AppendKey(res.Key, merkle.KeyEncodingURL)
+
+	prt := rootmulti.DefaultProofRuntime()
+	if len(res.Value) == 0 {
+		err = prt.VerifyAbsence(res.ProofOps, lb.AppHash, kp.String())
+	} else {
+		err = prt.VerifyValue(res.ProofOps, lb.AppHash, kp.String(), res.Value)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to verify the proof of the query response at height %d: %w", res.Height, err)
+	}
+	return nil
+}
+
+// QueryStore returns the value of key in the store of a module at height, proven against an
+// app hash verified by the light client. The latest height with a committed next header is
+// used if height is 0. The value is nil if the key is proven absent.
+func (cc *ChainClient) QueryStore(ctx context.Context, storeName string, key []byte, height int64) ([]byte, int64, error) {
+	// Fail before querying anything if the response can't be verified
+	if _, err := cc.Config.TrustOptions(); err != nil {
+		return nil, 0, err
+	}
+	// QueryABCI pins a height of 0 to the latest one with a committed next header
+	res, err := cc.QueryABCI(ctx, abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s/key", storeName),
+		Data:   key,
+		Height: height,
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return res.Value, res.Height, nil
+}
+
+// QueryVerifiedBalance returns the balance of denom of address at height, proven against an
+// app hash verified by the light client. It returns the height of the balance too.
+func (cc *ChainClient) QueryVerifiedBalance(ctx context.Context, address sdk.AccAddress, denom string, height int64) (sdk.Coin, int64, error) {
+	key := append(bankTypes.CreateAccountBalancesPrefix(address), []byte(denom)...)
+	bz, height, err := cc.QueryStore(ctx, bankTypes.StoreKey, key, height)
+	if err != nil {
+		return sdk.Coin{}, 0, err
+	}
+	balance, err := bankkeeper.UnmarshalBalanceCompat(cc.Codec.Marshaler, bz, denom)
+	if err != nil {
+		return sdk.Coin{}, 0, err
+	}
+	return balance, height, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmbytes "github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustOptions(t *testing.T) {
+	ccc := GetCosmosHubConfig(t.TempDir(), false)
+	_, err := ccc.TrustOptions()
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+	require.NoError(t, ccc.Validate())
+
+	ccc.TrustedHeight = 15000000
+	require.ErrorContains(t, ccc.Validate(), "invalid trusted header")
+
+	ccc.TrustedHash = "zz"
+	require.ErrorContains(t, ccc.Validate(), "invalid trusted-hash")
+
+	ccc.TrustedHash = strings.Repeat("0A", 32)
+	opts, err := ccc.TrustOptions()
+	require.NoError(t, err)
+	require.Equal(t, int64(15000000), opts.Height)
+	require.Equal(t, defaultTrustingPeriod, opts.Period)
+
+	ccc.TrustingPeriod = "72h"
+	opts, err = ccc.TrustOptions()
+	require.NoError(t, err)
+	require.Equal(t, 72*time.Hour, opts.Period)
+}
+
+// proofQueryClient answers ABCI queries without a proof at the height they were made.
+type proofQueryClient struct {
+	rpcclient.Client
+
+	latest int64
+	height int64
+}
+
+func (c *proofQueryClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: c.latest}}, nil
+}
+
+func (c *proofQueryClient) ABCIQueryWithOptions(_ context.Context, _ string, _ tmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	c.height = opts.Height
+	return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Height: opts.Height}}, nil
+}
+
+func TestQueryABCIProvenAtLatestHeight(t *testing.T) {
+	rpc := &proofQueryClient{latest: 100}
+	cc := &ChainClient{RPCClient: rpc}
+
+	// The latest height has no next header to verify the proof against yet
+	_, err := cc.QueryABCI(context.Background(), abci.RequestQuery{Path: "/store/bank/key", Prove: true})
+	require.ErrorContains(t, err, "no proof")
+	require.Equal(t, int64(99), rpc.height)
+}
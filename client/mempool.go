@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+// DefaultMempoolPollInterval is how often WatchMempool polls the mempool when no interval is given.
+const DefaultMempoolPollInterval = 2 * time.Second
+
+// MempoolTx is an unconfirmed tx decoded with the codec of the chain. Only the hash, size
+// and error are set when the tx can't be decoded.
+type MempoolTx struct {
+	Hash     string            `json:"hash"`
+	Size     int               `json:"size"`
+	Sender   string            `json:"sender,omitempty"`
+	Signers  []string          `json:"signers,omitempty"`
+	MsgTypes []string          `json:"msg_types,omitempty"`
+	Messages []json.RawMessage `json:"messages,omitempty"`
+	Fee      sdk.Coins         `json:"fee,omitempty"`
+	Gas      uint64            `json:"gas,omitempty"`
+	Memo     string            `json:"memo,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// MempoolEvent is a tx that entered the mempool, or left it when Removed is set.
+type MempoolEvent struct {
+	Removed bool
+	Tx      MempoolTx
+}
+
+// UnconfirmedTxs returns up to limit txs of the mempool of the node, decoded.
+func (cc *ChainClient) UnconfirmedTxs(ctx context.Context, limit int) ([]MempoolTx, error) {
+	res, err := cc.RPCClient.UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]MempoolTx, len(res.Txs))
+	for i, bz := range res.Txs {
+		txs[i] = cc.DecodeMempoolTx(bz)
+	}
+	return txs, nil
+}
+
+// DecodeMempoolTx decodes the bytes of an unconfirmed tx, the sender is its first signer.
+func (cc *ChainClient) DecodeMempoolTx(bz []byte) MempoolTx {
+	mtx := MempoolTx{Hash: fmt.Sprintf("%X", tmtypes.Tx(bz).Hash()), Size: len(bz)}
+	if err := cc.decodeMempoolTx(bz, &mtx); err != nil {
+		mtx.Error = err.Error()
+	}
+	return mtx
+}
+
+func (cc *ChainClient) decodeMempoolTx(bz []byte, mtx *MempoolTx) error {
+	tx, err := cc.DecodeTx(bz)
+	if err != nil {
+		return err
+	}
+	for _, msg := range tx.GetMsgs() {
+		msgJSON, err := cc.Codec.Marshaler.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return err
+		}
+		mtx.MsgTypes = append(mtx.MsgTypes, sdk.MsgTypeURL(msg))
+		mtx.Messages = append(mtx.Messages, msgJSON)
+	}
+	if mtx.Signers, err = cc.TxSigners(tx); err != nil {
+		return err
+	}
+	if len(mtx.Signers) > 0 {
+		mtx.Sender = mtx.Signers[0]
+	}
+	if feeTx, ok := tx.(sdk.FeeTx); ok {
+		mtx.Fee = feeTx.GetFee()
+		mtx.Gas = feeTx.GetGas()
+	}
+	if memoTx, ok := tx.(sdk.TxWithMemo); ok {
+		mtx.Memo = memoTx.GetMemo()
+	}
+	return nil
+}
+
+// WatchMempool polls the first limit txs of the mempool every interval, and returns a
+// channel of the txs entering and leaving it. The txs in the mempool at first are sent as
+// entering it. A tx leaves the mempool when it is included in a block or evicted, or when
+// more than limit txs are ahead of it. Polling errors are logged and retried, the channel
+// is closed when ctx is done. An interval of 0 or less polls every DefaultMempoolPollInterval.
+func (cc *ChainClient) WatchMempool(ctx context.Context, limit int, interval time.Duration) (<-chan MempoolEvent, error) {
+	if interval <= 0 {
+		interval = DefaultMempoolPollInterval
+	}
+	// Poll once before returning, so an unreachable node fails right away
+	txs, err := cc.RPCClient.UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan MempoolEvent)
+	go func() {
+		defer close(events)
+		send := func(ev MempoolEvent) bool {
+			select {
+			case events <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		pending := make(map[string]MempoolTx)
+		for {
+			seen := make(map[string]bool, len(txs.Txs))
+			for _, bz := range txs.Txs {
+				hash := fmt.Sprintf("%X", tmtypes.Tx(bz).Hash())
+				seen[hash] = true
+				if _, ok := pending[hash]; ok {
+					continue
+				}
+				mtx := cc.DecodeMempoolTx(bz)
+				pending[hash] = mtx
+				if !send(MempoolEvent{Tx: mtx}) {
+					return
+				}
+			}
+			for hash, mtx := range pending {
+				if seen[hash] {
+					continue
+				}
+				delete(pending, hash)
+				if !send(MempoolEvent{Removed: true, Tx: mtx}) {
+					return
+				}
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(interval):
+				}
+				if txs, err = cc.RPCClient.UnconfirmedTxs(ctx, &limit); err == nil {
+					break
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				cc.log.Warn("Failed to poll mempool", zap.Error(err))
+			}
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// mempoolClient serves the mempools in turn, then the last one.
+type mempoolClient struct {
+	rpcclient.Client
+
+	mu       sync.Mutex
+	mempools [][]tmtypes.Tx
+}
+
+func (c *mempoolClient) UnconfirmedTxs(context.Context, *int) (*coretypes.ResultUnconfirmedTxs, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	txs := c.mempools[0]
+	if len(c.mempools) > 1 {
+		c.mempools = c.mempools[1:]
+	}
+	return &coretypes.ResultUnconfirmedTxs{Count: len(txs), Txs: txs}, nil
+}
+
+func TestWatchMempool(t *testing.T) {
+	a, b, c := tmtypes.Tx("a"), tmtypes.Tx("b"), tmtypes.Tx("c")
+	cc := &ChainClient{
+		log:       zaptest.NewLogger(t),
+		Config:    &ChainClientConfig{AccountPrefix: "cosmos"},
+		Codec:     MakeCodec(ModuleBasics, nil),
+		RPCClient: &mempoolClient{mempools: [][]tmtypes.Tx{{a, b}, {b, c}, {c}}},
+	}
+
+	names := make(map[string]string)
+	for _, tx := range []tmtypes.Tx{a, b, c} {
+		names[cc.DecodeMempoolTx(tx).Hash] = string(tx)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events, err := cc.WatchMempool(ctx, 100, time.Millisecond)
+	require.NoError(t, err)
+
+	var got []string
+	for ev := range events {
+		change := "+"
+		if ev.Removed {
+			change = "-"
+		}
+		require.NotEmpty(t, ev.Tx.Error, "the txs can't be decoded")
+		got = append(got, change+names[ev.Tx.Hash])
+		if len(got) == 5 {
+			cancel()
+		}
+	}
+	require.Equal(t, []string{"+a", "+b", "+c", "-a", "-b"}, got)
+}
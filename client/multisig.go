@@ -0,0 +1,203 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// AddMultisigKey stores a multisig key made of the public keys of memberKeys in the
+// keyring, so it can be used to build, sign and combine txs of the multisig account.
+// Unless noSort is set the members are sorted by address, like the SDK CLI does, so
+// that the same members always result in the same multisig address.
+func (cc *ChainClient) AddMultisigKey(name string, memberKeys []string, threshold int, noSort bool) (address string, err error) {
+	if threshold <= 0 || threshold > len(memberKeys) {
+		return "", fmt.Errorf("threshold must be between 1 and the number of members (%d), got %d", len(memberKeys), threshold)
+	}
+
+	seen := make(map[string]bool, len(memberKeys))
+	pks := make([]cryptotypes.PubKey, len(memberKeys))
+	for i, member := range memberKeys {
+		if seen[member] {
+			return "", fmt.Errorf("duplicate multisig member key %s", member)
+		}
+		seen[member] = true
+
+		info, err := cc.Keybase.Key(member)
+		if err != nil {
+			return "", err
+		}
+		if pks[i], err = info.GetPubKey(); err != nil {
+			return "", err
+		}
+	}
+	if !noSort {
+		sort.Slice(pks, func(i, j int) bool {
+			return pks[i].Address().String() < pks[j].Address().String()
+		})
+	}
+
+	info, err := cc.Keybase.SaveMultisig(name, kmultisig.NewLegacyAminoPubKey(threshold, pks))
+	if err != nil {
+		return "", err
+	}
+	acc, err := info.GetAddress()
+	if err != nil {
+		return "", err
+	}
+	return cc.EncodeBech32AccAddr(acc)
+}
+
+// SignMultisigTx returns the partial signature of keyName, a member of the multisig
+// key multisigName, for sdkTx. accountNumber and sequence are those of the multisig
+// account. The signatures of enough members can be combined with CombineMultisigTx.
+// Like SignTx, it does not contact the chain.
+func (cc *ChainClient) SignMultisigTx(sdkTx sdk.Tx, keyName, multisigName string, accountNumber, sequence uint64) (signing.SignatureV2, error) {
+	multisigPub, multisigAddr, err := cc.multisigKey(multisigName)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+
+	info, err := cc.Keybase.Key(keyName)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	pk, err := info.GetPubKey()
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	if !isMultisigMember(pk, multisigPub) {
+		return signing.SignatureV2{}, fmt.Errorf("key %s is not a member of multisig %s", keyName, multisigName)
+	}
+
+	txb, err := cc.Codec.TxConfig.WrapTxBuilder(sdkTx)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	if !isTxSigner(multisigAddr, txb.GetTx()) {
+		return signing.SignatureV2{}, fmt.Errorf("multisig %s (%s) is not a signer of the tx", multisigName, cc.MustEncodeAccAddr(multisigAddr))
+	}
+
+	done := cc.SetSDKContext()
+	defer done()
+	if err := tx.Sign(cc.multisigTxFactory(accountNumber, sequence), keyName, txb, true); err != nil {
+		return signing.SignatureV2{}, err
+	}
+
+	sigs, err := txb.GetTx().GetSignaturesV2()
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	if len(sigs) != 1 {
+		return signing.SignatureV2{}, fmt.Errorf("expected a single signature, got %d", len(sigs))
+	}
+	return sigs[0], nil
+}
+
+// CombineMultisigTx verifies the partial signatures of the members of multisigName
+// and combines them into a signature of the multisig account, returning the tx
+// ready to be broadcast. accountNumber and sequence are those of the multisig account.
+func (cc *ChainClient) CombineMultisigTx(sdkTx sdk.Tx, multisigName string, accountNumber, sequence uint64, sigs []signing.SignatureV2) (client.TxBuilder, error) {
+	multisigPub, _, err := cc.multisigKey(multisigName)
+	if err != nil {
+		return nil, err
+	}
+
+	txb, err := cc.Codec.TxConfig.WrapTxBuilder(sdkTx)
+	if err != nil {
+		return nil, err
+	}
+
+	txf := cc.multisigTxFactory(accountNumber, sequence)
+	multisigSig := multisig.NewMultisig(len(multisigPub.PubKeys))
+	signers := make(map[string]bool, len(sigs))
+
+	done := cc.SetSDKContext()
+	defer done()
+	for _, sig := range sigs {
+		addr := cc.MustEncodeAccAddr(sdk.AccAddress(sig.PubKey.Address()))
+		if !isMultisigMember(sig.PubKey, multisigPub) {
+			return nil, fmt.Errorf("signature of %s is not from a member of multisig %s", addr, multisigName)
+		}
+		signerData := authsigning.SignerData{
+			Address:       addr,
+			ChainID:       txf.ChainID(),
+			AccountNumber: txf.AccountNumber(),
+			Sequence:      txf.Sequence(),
+			PubKey:        sig.PubKey,
+		}
+		if err := authsigning.VerifySignature(sig.PubKey, signerData, sig.Data, cc.Codec.TxConfig.SignModeHandler(), txb.GetTx()); err != nil {
+			return nil, fmt.Errorf("couldn't verify signature of %s: %w", addr, err)
+		}
+		if err := multisig.AddSignatureV2(multisigSig, sig, multisigPub.GetPubKeys()); err != nil {
+			return nil, err
+		}
+		signers[addr] = true
+	}
+	if len(signers) < int(multisigPub.Threshold) {
+		return nil, fmt.Errorf("multisig %s needs %d signatures, got %d", multisigName, multisigPub.Threshold, len(signers))
+	}
+
+	if err := txb.SetSignatures(signing.SignatureV2{
+		PubKey:   multisigPub,
+		Data:     multisigSig,
+		Sequence: sequence,
+	}); err != nil {
+		return nil, err
+	}
+	return txb, nil
+}
+
+// SignatureJSON encodes signatures as JSON, the format read by ParseSignatureJSON.
+func (cc *ChainClient) SignatureJSON(sigs ...signing.SignatureV2) ([]byte, error) {
+	return cc.Codec.TxConfig.MarshalSignatureJSON(sigs)
+}
+
+// ParseSignatureJSON decodes signatures encoded with SignatureJSON.
+func (cc *ChainClient) ParseSignatureJSON(bz []byte) ([]signing.SignatureV2, error) {
+	return cc.Codec.TxConfig.UnmarshalSignatureJSON(bz)
+}
+
+// multisigKey returns the public key and address of the multisig key name.
+func (cc *ChainClient) multisigKey(name string) (*kmultisig.LegacyAminoPubKey, sdk.AccAddress, error) {
+	info, err := cc.Keybase.Key(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, err := info.GetPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	multisigPub, ok := pk.(*kmultisig.LegacyAminoPubKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("key %s is not a multisig key", name)
+	}
+	return multisigPub, sdk.AccAddress(multisigPub.Address()), nil
+}
+
+// multisigTxFactory returns the factory to sign txs of a multisig account with.
+// Members sign with amino JSON, the only sign mode multisigs support.
+func (cc *ChainClient) multisigTxFactory(accountNumber, sequence uint64) tx.Factory {
+	return cc.TxFactory().
+		WithAccountNumber(accountNumber).
+		WithSequence(sequence).
+		WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+}
+
+// isMultisigMember reports whether pk is one of the keys of multisigPub.
+func isMultisigMember(pk cryptotypes.PubKey, multisigPub *kmultisig.LegacyAminoPubKey) bool {
+	for _, member := range multisigPub.GetPubKeys() {
+		if member.Equals(pk) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMultisigSignAndCombine(t *testing.T) {
+	homepath := t.TempDir()
+	cl, err := client.NewChainClient(
+		zaptest.NewLogger(t),
+		client.GetCosmosHubConfig(homepath, true),
+		homepath, nil, nil,
+	)
+	require.NoError(t, err)
+
+	members := []string{"alice", "bob", "carol"}
+	for _, name := range members {
+		_, err := cl.AddKey(name, sdk.CoinType)
+		require.NoError(t, err)
+	}
+
+	_, err = cl.AddMultisigKey("treasury", members, 4, false)
+	require.Error(t, err)
+	address, err := cl.AddMultisigKey("treasury", members, 2, false)
+	require.NoError(t, err)
+
+	to, err := cl.ShowAddress("alice")
+	require.NoError(t, err)
+	txb, err := cl.BuildUnsignedTx(context.Background(), []sdk.Msg{&banktypes.MsgSend{
+		FromAddress: address,
+		ToAddress:   to,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 100)),
+	}}, "", client.WithGas(100000))
+	require.NoError(t, err)
+	unsigned := txb.GetTx()
+
+	var sigs []signing.SignatureV2
+	for _, name := range members[:2] {
+		sig, err := cl.SignMultisigTx(unsigned, name, "treasury", 12, 3)
+		require.NoError(t, err)
+
+		// Partial signatures are passed around as JSON.
+		bz, err := cl.SignatureJSON(sig)
+		require.NoError(t, err)
+		parsed, err := cl.ParseSignatureJSON(bz)
+		require.NoError(t, err)
+		sigs = append(sigs, parsed...)
+	}
+
+	_, err = cl.CombineMultisigTx(unsigned, "treasury", 12, 3, sigs[:1])
+	require.ErrorContains(t, err, "needs 2 signatures")
+
+	// A signature for another sequence doesn't verify.
+	_, err = cl.CombineMultisigTx(unsigned, "treasury", 12, 4, sigs)
+	require.ErrorContains(t, err, "couldn't verify signature")
+
+	signed, err := cl.CombineMultisigTx(unsigned, "treasury", 12, 3, sigs)
+	require.NoError(t, err)
+	combined, err := signed.GetTx().GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, combined, 1)
+	require.IsType(t, &kmultisig.LegacyAminoPubKey{}, combined[0].PubKey)
+	require.Equal(t, uint64(3), combined[0].Sequence)
+}
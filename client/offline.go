@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// BuildUnsignedTx builds a transaction for msgs without signing it, so it can
+// be signed offline with SignTx and broadcast later with BroadcastSignedTx.
+// The gas is simulated using the configured key unless it is set with WithGas.
+func (cc *ChainClient) BuildUnsignedTx(ctx context.Context, msgs []sdk.Msg, memo string, opts ...TxOption) (client.TxBuilder, error) {
+	o := cc.txOptionsFromConfig(opts...)
+
+	txf := cc.TxFactory()
+	if o.gas == 0 {
+		if err := cc.checkSimulatable(o); err != nil {
+			return nil, err
+		}
+		// Simulating needs the account number and sequence of the simulating key.
+		var err error
+		if txf, err = cc.PrepareFactory(txf); err != nil {
+			return nil, fmt.Errorf("simulating gas of tx (set the gas to skip this): %w", err)
+		}
+	}
+
+	return cc.buildTx(ctx, txf, msgs, memo, o)
+}
+
+// SignTx signs sdkTx with keyName using the given account number and sequence.
+// It does not contact the chain, so it can be used on a machine without network
+// access. Existing signatures are kept unless overwriteSig is set, which allows
+// several signers to sign the same tx one after another.
+func (cc *ChainClient) SignTx(sdkTx sdk.Tx, keyName string, accountNumber, sequence uint64, overwriteSig bool) (client.TxBuilder, error) {
+	txb, err := cc.Codec.TxConfig.WrapTxBuilder(sdkTx)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := cc.Keybase.Key(keyName)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := info.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	if !isTxSigner(addr, txb.GetTx()) {
+		return nil, fmt.Errorf("key %s (%s) is not a signer of the tx", keyName, cc.MustEncodeAccAddr(addr))
+	}
+
+	txf := cc.TxFactory().
+		WithAccountNumber(accountNumber).
+		WithSequence(sequence)
+
+	done := cc.SetSDKContext()
+	defer done()
+	if err := tx.Sign(txf, keyName, txb, overwriteSig); err != nil {
+		return nil, err
+	}
+	return txb, nil
+}
+
+// BroadcastSignedTx broadcasts a tx that has been signed with SignTx. Like
+// SendMsgs, a tx that was included in a block but failed returns an error
+// alongside the response.
+func (cc *ChainClient) BroadcastSignedTx(ctx context.Context, tx sdk.Tx, opts ...TxOption) (*sdk.TxResponse, error) {
+	txBytes, err := cc.Codec.TxConfig.TxEncoder()(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cc.BroadcastTx(ctx, txBytes, opts...)
+	if err != nil {
+		return res, err
+	}
+	if res.Code != 0 {
+		return res, fmt.Errorf("transaction failed with code: %d", res.Code)
+	}
+	return res, nil
+}
+
+// TxJSON encodes tx as JSON, the format read by ParseTxJSON.
+func (cc *ChainClient) TxJSON(tx sdk.Tx) ([]byte, error) {
+	done := cc.SetSDKContext()
+	defer done()
+	return cc.Codec.TxConfig.TxJSONEncoder()(tx)
+}
+
+// ParseTxJSON decodes a tx encoded with TxJSON.
+func (cc *ChainClient) ParseTxJSON(bz []byte) (sdk.Tx, error) {
+	done := cc.SetSDKContext()
+	defer done()
+	return cc.Codec.TxConfig.TxJSONDecoder()(bz)
+}
+
+// DecodeTx decodes the bytes of a tx, like those of a block or of the mempool.
+func (cc *ChainClient) DecodeTx(bz []byte) (sdk.Tx, error) {
+	return cc.Codec.TxConfig.TxDecoder()(bz)
+}
+
+// EncodeTx encodes tx to the bytes that are broadcast, the bytes read by DecodeTx.
+func (cc *ChainClient) EncodeTx(tx sdk.Tx) ([]byte, error) {
+	return cc.Codec.TxConfig.TxEncoder()(tx)
+}
+
+// TxSigners returns the addresses of the signers of tx with the bech32 prefix of the chain.
+func (cc *ChainClient) TxSigners(tx sdk.Tx) ([]string, error) {
+	sigTx, ok := tx.(authsigning.Tx)
+	if !ok {
+		return nil, fmt.Errorf("expecting a tx with signers, got: %T", tx)
+	}
+	// The messages parse their signers with the global bech32 prefix
+	done := cc.SetSDKContext()
+	defer done()
+	var signers []string
+	for _, signer := range sigTx.GetSigners() {
+		addr, err := cc.EncodeBech32AccAddr(signer)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, addr)
+	}
+	return signers, nil
+}
+
+// ParseTxBytes parses the bytes of a tx encoded as hex, with or without a 0x prefix, or
+// as base64.
+func ParseTxBytes(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if hexStr := strings.TrimPrefix(s, "0x"); len(hexStr)%2 == 0 {
+		if bz, err := hex.DecodeString(hexStr); err == nil {
+			return bz, nil
+		}
+	}
+	bz, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("expected the tx bytes encoded as hex or base64")
+	}
+	return bz, nil
+}
+
+// isTxSigner reports whether addr is one of the signers of tx.
+func isTxSigner(addr sdk.AccAddress, tx authsigning.Tx) bool {
+	for _, signer := range tx.GetSigners() {
+		if signer.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/strangelove-ventures/lens/client/codecs/ethermint"
+	"github.com/strangelove-ventures/lens/client/codecs/injective"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTxExtensionOptions(t *testing.T) {
+	for _, tc := range []struct {
+		codec, prefix string
+		option        proto.Message
+		typeURL       string
+	}{
+		{"ethermint", "evmos", &ethermint.ExtensionOptionsWeb3Tx{TypedDataChainID: 9001}, "/ethermint.types.v1.ExtensionOptionsWeb3Tx"},
+		{"injective", "inj", &injective.ExtensionOptionsWeb3Tx{TypedDataChainID: 888}, "/injective.evm.v1beta1.ExtensionOptionsWeb3Tx"},
+	} {
+		t.Run(tc.codec, func(t *testing.T) {
+			cc := &ChainClient{
+				Config: &ChainClientConfig{AccountPrefix: tc.prefix},
+				Codec:  MakeCodec(ModuleBasics, []string{tc.codec}),
+			}
+			from, err := cc.EncodeBech32AccAddr(sdk.AccAddress("from________________"))
+			require.NoError(t, err)
+			to, err := cc.EncodeBech32AccAddr(sdk.AccAddress("to__________________"))
+			require.NoError(t, err)
+
+			builder := cc.Codec.TxConfig.NewTxBuilder()
+			require.NoError(t, builder.SetMsgs(&bankTypes.MsgSend{FromAddress: from, ToAddress: to, Amount: sdk.NewCoins(sdk.NewInt64Coin("aevmos", 5))}))
+			option, err := codectypes.NewAnyWithValue(tc.option)
+			require.NoError(t, err)
+			builder.(authtx.ExtensionOptionsTxBuilder).SetExtensionOptions(option)
+			bz, err := cc.EncodeTx(builder.GetTx())
+			require.NoError(t, err)
+
+			tx, err := cc.DecodeTx(bz)
+			require.NoError(t, err)
+			signers, err := cc.TxSigners(tx)
+			require.NoError(t, err)
+			require.Equal(t, []string{from}, signers)
+			txJSON, err := cc.TxJSON(tx)
+			require.NoError(t, err)
+			require.Contains(t, string(txJSON), tc.typeURL)
+
+			reencoded, err := cc.EncodeTx(tx)
+			require.NoError(t, err)
+			require.Equal(t, bz, reencoded)
+		})
+	}
+}
+
+func TestParseTxBytes(t *testing.T) {
+	bz := []byte{0x0a, 0x92, 0x01, 0xff}
+	for _, s := range []string{hex.EncodeToString(bz), "0x" + hex.EncodeToString(bz), base64.StdEncoding.EncodeToString(bz)} {
+		got, err := ParseTxBytes(s)
+		require.NoError(t, err)
+		require.Equal(t, bz, got)
+	}
+	_, err := ParseTxBytes("not a tx!")
+	require.ErrorContains(t, err, "hex or base64")
+}
@@ -0,0 +1,63 @@
+// Package pagination follows the pages of the list queries of the Cosmos SDK. It only
+// depends on the SDK, so both the client and its query package use it.
+package pagination
+
+import (
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// Collect collects the items of a list query. fetch is called with pageReq, then with the
+// next key of each page until there are no pages left or maxItems items are collected, 0
+// collects all of them. The limit of pageReq is the page size, query.DefaultLimit is used
+// if it is 0.
+//
+// The returned page response has the total of the first page, if it was requested,
+// and the next key to continue from when maxItems stopped the pagination.
+func Collect[S ~[]T, T any](pageReq *query.PageRequest, maxItems uint64, fetch func(*query.PageRequest) (S, *query.PageResponse, error)) (S, *query.PageResponse, error) {
+	var pr query.PageRequest
+	if pageReq != nil {
+		pr = *pageReq
+	}
+	pageSize := pr.Limit
+	if pageSize == 0 {
+		pageSize = query.DefaultLimit
+	}
+
+	var (
+		items    S
+		pageRes  = &query.PageResponse{}
+		firstReq = true
+	)
+	for {
+		pr.Limit = pageSize
+		if maxItems > 0 && maxItems-uint64(len(items)) < pageSize {
+			pr.Limit = maxItems - uint64(len(items))
+		}
+
+		page, res, err := fetch(&pr)
+		if err != nil {
+			return nil, nil, err
+		}
+		if uint64(len(page)) > pr.Limit {
+			page = page[:pr.Limit]
+		}
+		items = append(items, page...)
+		if res == nil {
+			return items, pageRes, nil
+		}
+		if firstReq {
+			pageRes.Total = res.Total
+			firstReq = false
+		}
+		pageRes.NextKey = res.NextKey
+
+		if len(res.NextKey) == 0 || (maxItems > 0 && uint64(len(items)) >= maxItems) {
+			return items, pageRes, nil
+		}
+
+		// The offset only applies to the first page and the total is only counted for it.
+		pr.Key = res.NextKey
+		pr.Offset = 0
+		pr.CountTotal = false
+	}
+}
@@ -0,0 +1,66 @@
+package pagination
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedItems serves the items 0 to n-1 like a keyed store, the key of an item is its decimal index.
+func pagedItems(t *testing.T, n int, requests *[]query.PageRequest) func(*query.PageRequest) ([]int, *query.PageResponse, error) {
+	return func(pr *query.PageRequest) ([]int, *query.PageResponse, error) {
+		*requests = append(*requests, *pr)
+		start := int(pr.Offset)
+		if len(pr.Key) > 0 {
+			require.Zero(t, pr.Offset, "offset with a key")
+			var err error
+			start, err = strconv.Atoi(string(pr.Key))
+			require.NoError(t, err)
+		}
+		end := start + int(pr.Limit)
+		if end > n {
+			end = n
+		}
+		var items []int
+		for i := start; i < end; i++ {
+			items = append(items, i)
+		}
+		res := &query.PageResponse{}
+		if end < n {
+			res.NextKey = []byte(strconv.Itoa(end))
+		}
+		if pr.CountTotal {
+			res.Total = uint64(n)
+		}
+		return items, res, nil
+	}
+}
+
+func TestCollect(t *testing.T) {
+	var requests []query.PageRequest
+	items, page, err := Collect(&query.PageRequest{Limit: 10, CountTotal: true}, 0, pagedItems(t, 25, &requests))
+	require.NoError(t, err)
+	require.Len(t, items, 25)
+	require.Equal(t, 24, items[24])
+	require.Equal(t, uint64(25), page.Total)
+	require.Empty(t, page.NextKey)
+	require.Len(t, requests, 3)
+	require.False(t, requests[1].CountTotal)
+
+	// The last page is shortened to stop at maxItems, and continues from the offset.
+	requests = nil
+	items, page, err = Collect(&query.PageRequest{Limit: 10, Offset: 5}, 12, pagedItems(t, 25, &requests))
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, items)
+	require.Equal(t, []byte("17"), page.NextKey)
+	require.Equal(t, uint64(2), requests[1].Limit)
+
+	// Without a page size the default limit is used.
+	requests = nil
+	items, _, err = Collect(nil, 0, pagedItems(t, 150, &requests))
+	require.NoError(t, err)
+	require.Len(t, items, 150)
+	require.Equal(t, uint64(query.DefaultLimit), requests[0].Limit)
+}
@@ -15,20 +15,21 @@ import (
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	distTypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/lens/client/pagination"
+	"go.uber.org/zap"
 )
 
 // queryBalanceWithAddress returns the amount of coins in the relayer account with address as input
-// TODO add pagination support
-func (cc *ChainClient) queryBalanceWithAddress(ctx context.Context, address string) (sdk.Coins, error) {
-	p := &bankTypes.QueryAllBalancesRequest{Address: address, Pagination: DefaultPageRequest()}
+func (cc *ChainClient) queryBalanceWithAddress(ctx context.Context, address string, pageReq *query.PageRequest) (sdk.Coins, error) {
 	queryClient := bankTypes.NewQueryClient(cc)
-
-	res, err := queryClient.AllBalances(ctx, p)
-	if err != nil {
-		return nil, err
-	}
-
-	return res.Balances, nil
+	coins, _, err := pagination.Collect(pageReq, 0, func(pr *query.PageRequest) (sdk.Coins, *query.PageResponse, error) {
+		res, err := queryClient.AllBalances(ctx, &bankTypes.QueryAllBalancesRequest{Address: address, Pagination: pr})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Balances, res.Pagination, nil
+	})
+	return coins, err
 }
 
 func (cc *ChainClient) queryLatestHeight(ctx context.Context) (int64, error) {
@@ -42,18 +43,16 @@ func (cc *ChainClient) queryLatestHeight(ctx context.Context) (int64, error) {
 }
 
 // queryDenomTraces returns all the denom traces from a given chain
-// TODO add pagination support
-func (cc *ChainClient) queryDenomTraces(ctx context.Context, offset, limit uint64, height int64) ([]transfertypes.DenomTrace, error) {
-	transfers, err := transfertypes.NewQueryClient(cc).DenomTraces(
-		ctx,
-		&transfertypes.QueryDenomTracesRequest{
-			Pagination: DefaultPageRequest(),
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-	return transfers.DenomTraces, nil
+func (cc *ChainClient) queryDenomTraces(ctx context.Context, pageReq *query.PageRequest) ([]transfertypes.DenomTrace, error) {
+	queryClient := transfertypes.NewQueryClient(cc)
+	traces, _, err := pagination.Collect(pageReq, 0, func(pr *query.PageRequest) (transfertypes.Traces, *query.PageResponse, error) {
+		res, err := queryClient.DenomTraces(ctx, &transfertypes.QueryDenomTracesRequest{Pagination: pr})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.DenomTraces, res.Pagination, nil
+	})
+	return traces, err
 }
 
 func (cc *ChainClient) QueryAccount(ctx context.Context, address sdk.AccAddress) (authtypes.AccountI, error) {
@@ -72,24 +71,22 @@ func (cc *ChainClient) QueryAccount(ctx context.Context, address sdk.AccAddress)
 	return acc, nil
 }
 
-// QueryBalanceWithDenomTraces is a helper function for query balance
+// QueryBalanceWithDenomTraces is a helper function for query balance, it returns all the balances
+// of address with the denom path of ibc denoms. The pages of the query have the limit of pageReq.
 func (cc *ChainClient) QueryBalanceWithDenomTraces(ctx context.Context, address sdk.AccAddress, pageReq *query.PageRequest) (sdk.Coins, error) {
-	coins, err := cc.queryBalanceWithAddress(ctx, cc.MustEncodeAccAddr(address))
+	snapshot, err := cc.Snapshot(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// Query the balances and denom traces at the same height, in case the pages span several blocks
+	ctx = snapshot.Context(ctx)
 
-	h, err := cc.queryLatestHeight(ctx)
+	coins, err := cc.queryBalanceWithAddress(ctx, cc.MustEncodeAccAddr(address), pageReq)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: figure out how to handle this
-	// we don't want to expose user to this
-	// so maybe we need a QueryAllDenomTraces function
-	// that will paginate the responses automatically
-	// TODO fix pagination here later
-	dts, err := cc.queryDenomTraces(ctx, 0, 1000, h)
+	dts, err := cc.queryDenomTraces(ctx, &query.PageRequest{Limit: pageReq.GetLimit()})
 	if err != nil {
 		return nil, err
 	}
@@ -250,6 +247,97 @@ func (cc *ChainClient) QueryTxs(ctx context.Context, page, limit int, events []s
 	return res.Txs, nil
 }
 
+// maxTxSearchPerPage is the largest page of tx searches CometBFT serves.
+const maxTxSearchPerPage = 100
+
+// TxSearchResult is a page of the txs matching a search. The txs that can't be decoded are
+// left out of Txs, and listed in Undecodable with their raw result instead.
+type TxSearchResult struct {
+	*sdk.SearchTxsResult
+	Undecodable []UndecodableTx
+}
+
+// UndecodableTx is the raw result of a tx of a search that can't be decoded, and why.
+type UndecodableTx struct {
+	Result *ctypes.ResultTx `json:"result"`
+	Error  string           `json:"error"`
+}
+
+// SearchTxs returns a page of the txs matching all the events, conditions like
+// "message.sender='cosmos1...'", decoded like the result of QueryTx. orderBy is "asc" or
+// "desc" by height, "asc" if empty.
+func (cc *ChainClient) SearchTxs(ctx context.Context, events []string, page, perPage int, orderBy string) (*TxSearchResult, error) {
+	if len(events) == 0 {
+		return nil, errors.New("must declare at least one event to search")
+	}
+	if page <= 0 {
+		return nil, errors.New("page must greater than 0")
+	}
+	if perPage <= 0 || perPage > maxTxSearchPerPage {
+		return nil, fmt.Errorf("per page must be between 1 and %d", maxTxSearchPerPage)
+	}
+
+	res, err := cc.RPCClient.TxSearch(ctx, strings.Join(events, " AND "), false, &page, &perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	txs, undecodable := cc.mkTxResults(ctx, res.Txs)
+	return &TxSearchResult{
+		SearchTxsResult: sdk.NewSearchTxsResult(uint64(res.TotalCount), uint64(len(txs)), uint64(page), uint64(perPage), txs),
+		Undecodable:     undecodable,
+	}, nil
+}
+
+// SearchAllTxs returns all the txs matching all the events, fetching every page of the search.
+func (cc *ChainClient) SearchAllTxs(ctx context.Context, events []string, orderBy string) (*TxSearchResult, error) {
+	var (
+		txs         []*sdk.TxResponse
+		undecodable []UndecodableTx
+	)
+	for page := 1; ; page++ {
+		res, err := cc.SearchTxs(ctx, events, page, maxTxSearchPerPage, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, res.Txs...)
+		undecodable = append(undecodable, res.Undecodable...)
+		found := len(txs) + len(undecodable)
+		if len(res.Txs)+len(res.Undecodable) < maxTxSearchPerPage || uint64(found) >= res.TotalCount {
+			count := uint64(len(txs))
+			limit := uint64(found)
+			if limit == 0 {
+				limit = maxTxSearchPerPage
+			}
+			return &TxSearchResult{
+				SearchTxsResult: sdk.NewSearchTxsResult(uint64(found), count, 1, limit, txs),
+				Undecodable:     undecodable,
+			}, nil
+		}
+	}
+}
+
+// mkTxResults decodes the txs of a search. The txs that can't be decoded are skipped with
+// a warning and returned apart with their raw result, rather than failing the search.
+func (cc *ChainClient) mkTxResults(ctx context.Context, resTxs []*ctypes.ResultTx) ([]*sdk.TxResponse, []UndecodableTx) {
+	txs := make([]*sdk.TxResponse, 0, len(resTxs))
+	var undecodable []UndecodableTx
+	for _, resTx := range resTxs {
+		tx, err := cc.mkTxResult(ctx, resTx)
+		if err != nil {
+			cc.log.Warn(
+				"Skipping undecodable tx of search",
+				zap.String("hash", resTx.Hash.String()),
+				zap.Int64("height", resTx.Height),
+				zap.Error(err),
+			)
+			undecodable = append(undecodable, UndecodableTx{Result: resTx, Error: err.Error()})
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	return txs, undecodable
+}
+
 func DefaultPageRequest() *query.PageRequest {
 	return &query.PageRequest{
 		Key:        []byte(""),
@@ -1,13 +1,15 @@
 package query
 
 import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
 // bank_ParamsRPC returns the distribution params
 func bank_ParamsRPC(q *Query) (*bankTypes.QueryParamsResponse, error) {
 	req := &bankTypes.QueryParamsRequest{}
-	queryClient := bankTypes.NewQueryClient(q.Client)
+	queryClient := bankTypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.Params(ctx, req)
@@ -20,7 +22,7 @@ func bank_ParamsRPC(q *Query) (*bankTypes.QueryParamsResponse, error) {
 // bank_BalanceRPC returns the balance of specified denom coins for a single account.
 func bank_BalanceRPC(q *Query, address string, denom string) (*bankTypes.QueryBalanceResponse, error) {
 	req := &bankTypes.QueryBalanceRequest{Address: address, Denom: denom}
-	queryClient := bankTypes.NewQueryClient(q.Client)
+	queryClient := bankTypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.Balance(ctx, req)
@@ -32,21 +34,27 @@ func bank_BalanceRPC(q *Query, address string, denom string) (*bankTypes.QueryBa
 
 // bank_AllBalancesRPC returns the balance of all coins for a single account.
 func bank_AllBalancesRPC(q *Query, address string) (*bankTypes.QueryAllBalancesResponse, error) {
-	req := &bankTypes.QueryAllBalancesRequest{Address: address, Pagination: q.Options.Pagination}
-	queryClient := bankTypes.NewQueryClient(q.Client)
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.AllBalances(ctx, req)
+	balances, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) (sdk.Coins, *query.PageResponse, error) {
+		queryClient := bankTypes.NewQueryClient(q)
+		req := &bankTypes.QueryAllBalancesRequest{Address: address, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.AllBalances(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Balances, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &bankTypes.QueryAllBalancesResponse{Balances: balances, Pagination: page}, nil
 }
 
 // bank_SupplyOfRPC returns the supply of all coins
 func bank_SupplyOfRPC(q *Query, denom string) (*bankTypes.QuerySupplyOfResponse, error) {
 	req := &bankTypes.QuerySupplyOfRequest{Denom: denom}
-	queryClient := bankTypes.NewQueryClient(q.Client)
+	queryClient := bankTypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.SupplyOf(ctx, req)
@@ -58,21 +66,27 @@ func bank_SupplyOfRPC(q *Query, denom string) (*bankTypes.QuerySupplyOfResponse,
 
 // bank_TotalSupplyRPC returns the supply of all coins
 func bank_TotalSupplyRPC(q *Query) (*bankTypes.QueryTotalSupplyResponse, error) {
-	req := &bankTypes.QueryTotalSupplyRequest{Pagination: q.Options.Pagination}
-	queryClient := bankTypes.NewQueryClient(q.Client)
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.TotalSupply(ctx, req)
+	supply, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) (sdk.Coins, *query.PageResponse, error) {
+		queryClient := bankTypes.NewQueryClient(q)
+		req := &bankTypes.QueryTotalSupplyRequest{Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.TotalSupply(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Supply, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &bankTypes.QueryTotalSupplyResponse{Supply: supply, Pagination: page}, nil
 }
 
 // bank_DenomMetadataRPC returns the metadata for given denom
 func bank_DenomMetadataRPC(q *Query, denom string) (*bankTypes.QueryDenomMetadataResponse, error) {
 	req := &bankTypes.QueryDenomMetadataRequest{Denom: denom}
-	queryClient := bankTypes.NewQueryClient(q.Client)
+	queryClient := bankTypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.DenomMetadata(ctx, req)
@@ -84,13 +98,19 @@ func bank_DenomMetadataRPC(q *Query, denom string) (*bankTypes.QueryDenomMetadat
 
 // bank_DenomsMetadataRPC returns the metadata for all denoms
 func bank_DenomsMetadataRPC(q *Query) (*bankTypes.QueryDenomsMetadataResponse, error) {
-	req := &bankTypes.QueryDenomsMetadataRequest{Pagination: q.Options.Pagination}
-	queryClient := bankTypes.NewQueryClient(q.Client)
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.DenomsMetadata(ctx, req)
+	metadatas, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]bankTypes.Metadata, *query.PageResponse, error) {
+		queryClient := bankTypes.NewQueryClient(q)
+		req := &bankTypes.QueryDenomsMetadataRequest{Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.DenomsMetadata(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Metadatas, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &bankTypes.QueryDenomsMetadataResponse{Metadatas: metadatas, Pagination: page}, nil
 }
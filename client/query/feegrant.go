@@ -0,0 +1,57 @@
+package query
+
+import (
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// feegrant_AllowanceRPC returns the fee allowance granted by granter to grantee
+func feegrant_AllowanceRPC(q *Query, granter string, grantee string) (*feegrant.QueryAllowanceResponse, error) {
+	req := &feegrant.QueryAllowanceRequest{Granter: granter, Grantee: grantee}
+	queryClient := feegrant.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.Allowance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// feegrant_AllowancesRPC returns all the fee allowances granted to grantee
+func feegrant_AllowancesRPC(q *Query, grantee string) (*feegrant.QueryAllowancesResponse, error) {
+	allowances, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*feegrant.Grant, *query.PageResponse, error) {
+		queryClient := feegrant.NewQueryClient(q)
+		req := &feegrant.QueryAllowancesRequest{Grantee: grantee, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.Allowances(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Allowances, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &feegrant.QueryAllowancesResponse{Allowances: allowances, Pagination: page}, nil
+}
+
+// feegrant_AllowancesByGranterRPC returns all the fee allowances granted by granter
+func feegrant_AllowancesByGranterRPC(q *Query, granter string) (*feegrant.QueryAllowancesByGranterResponse, error) {
+	allowances, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*feegrant.Grant, *query.PageResponse, error) {
+		queryClient := feegrant.NewQueryClient(q)
+		req := &feegrant.QueryAllowancesByGranterRequest{Granter: granter, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.AllowancesByGranter(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Allowances, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &feegrant.QueryAllowancesByGranterResponse{Allowances: allowances, Pagination: page}, nil
+}
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"github.com/cosmos/cosmos-sdk/types/query"
+	govTypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+)
+
+// gov_ProposalRPC returns the proposal with the given id
+func gov_ProposalRPC(q *Query, id uint64) (*govTypes.QueryProposalResponse, error) {
+	req := &govTypes.QueryProposalRequest{ProposalId: id}
+	queryClient := govTypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.Proposal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// gov_ProposalsRPC returns the proposals matching the given filters, empty filters match all proposals
+func gov_ProposalsRPC(q *Query, status govTypes.ProposalStatus, voter string, depositor string) (*govTypes.QueryProposalsResponse, error) {
+	proposals, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*govTypes.Proposal, *query.PageResponse, error) {
+		queryClient := govTypes.NewQueryClient(q)
+		req := &govTypes.QueryProposalsRequest{ProposalStatus: status, Voter: voter, Depositor: depositor, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.Proposals(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Proposals, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &govTypes.QueryProposalsResponse{Proposals: proposals, Pagination: page}, nil
+}
+
+// gov_VoteRPC returns the vote of voter on a proposal
+func gov_VoteRPC(q *Query, id uint64, voter string) (*govTypes.QueryVoteResponse, error) {
+	req := &govTypes.QueryVoteRequest{ProposalId: id, Voter: voter}
+	queryClient := govTypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.Vote(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// gov_VotesRPC returns the votes on a proposal
+func gov_VotesRPC(q *Query, id uint64) (*govTypes.QueryVotesResponse, error) {
+	votes, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*govTypes.Vote, *query.PageResponse, error) {
+		queryClient := govTypes.NewQueryClient(q)
+		req := &govTypes.QueryVotesRequest{ProposalId: id, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.Votes(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Votes, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &govTypes.QueryVotesResponse{Votes: votes, Pagination: page}, nil
+}
+
+// gov_DepositRPC returns the deposit of depositor on a proposal
+func gov_DepositRPC(q *Query, id uint64, depositor string) (*govTypes.QueryDepositResponse, error) {
+	req := &govTypes.QueryDepositRequest{ProposalId: id, Depositor: depositor}
+	queryClient := govTypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.Deposit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// gov_DepositsRPC returns the deposits on a proposal
+func gov_DepositsRPC(q *Query, id uint64) (*govTypes.QueryDepositsResponse, error) {
+	deposits, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*govTypes.Deposit, *query.PageResponse, error) {
+		queryClient := govTypes.NewQueryClient(q)
+		req := &govTypes.QueryDepositsRequest{ProposalId: id, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.Deposits(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Deposits, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &govTypes.QueryDepositsResponse{Deposits: deposits, Pagination: page}, nil
+}
+
+// gov_TallyResultRPC returns the tally of the votes on a proposal
+func gov_TallyResultRPC(q *Query, id uint64) (*govTypes.QueryTallyResultResponse, error) {
+	req := &govTypes.QueryTallyResultRequest{ProposalId: id}
+	queryClient := govTypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.TallyResult(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// gov_ParamsRPC returns the gov params of the given type, one of deposit, voting or tallying
+func gov_ParamsRPC(q *Query, paramsType string) (*govTypes.QueryParamsResponse, error) {
+	req := &govTypes.QueryParamsRequest{ParamsType: paramsType}
+	queryClient := govTypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.Params(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultHistoryWorkers is the number of heights History queries at the same time by default.
+const DefaultHistoryWorkers = 4
+
+// HistoryPoint is what an address held at a height.
+type HistoryPoint struct {
+	Height      int64                            `json:"height" yaml:"height"`
+	Time        time.Time                        `json:"time" yaml:"time"`
+	Balances    sdk.Coins                        `json:"balances" yaml:"balances"`
+	Delegations stakingTypes.DelegationResponses `json:"delegations" yaml:"delegations"`
+	Rewards     sdk.DecCoins                     `json:"rewards" yaml:"rewards"`
+}
+
+// HistoryHeights returns the heights from start to end with step blocks between them, end
+// is always the last height. A step of 0 returns start and end only.
+func HistoryHeights(start, end, step int64) ([]int64, error) {
+	switch {
+	case start <= 0 || end < start:
+		return nil, errors.New("the start height must be positive and not after the end height")
+	case step < 0:
+		return nil, errors.New("the step must not be negative")
+	case step == 0 || step > end-start:
+		step = end - start
+	}
+	if start == end {
+		return []int64{start}, nil
+	}
+	var heights []int64
+	for h := start; h < end; h += step {
+		heights = append(heights, h)
+	}
+	return append(heights, end), nil
+}
+
+// History returns the balances, delegations and pending rewards of address at each height,
+// in the order of the heights. workers heights are queried at the same time, or
+// DefaultHistoryWorkers if it is 0. All the items of the list queries are collected.
+func (q *Query) History(ctx context.Context, address string, heights []int64, workers int) ([]HistoryPoint, error) {
+	if workers <= 0 {
+		workers = DefaultHistoryWorkers
+	}
+	points := make([]HistoryPoint, len(heights))
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(workers)
+	for i, height := range heights {
+		i, height := i, height
+		eg.Go(func() error {
+			point, err := q.historyPoint(ctx, address, height)
+			if err != nil {
+				return err
+			}
+			points[i] = point
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+func (q *Query) historyPoint(ctx context.Context, address string, height int64) (HistoryPoint, error) {
+	opts := QueryOptions{}
+	if q.Options != nil {
+		opts = *q.Options
+	}
+	opts.Height = height
+	opts.MaxItems = 0
+	pq := &Query{Client: q.Client, Options: &opts, ctx: ctx}
+
+	blockTime, err := q.Client.BlockTime(ctx, height)
+	if err != nil {
+		return HistoryPoint{}, err
+	}
+	balances, err := pq.Bank_Balances(address)
+	if err != nil {
+		return HistoryPoint{}, err
+	}
+	delegations, err := pq.Staking_DelegatorDelegations(address)
+	if err != nil {
+		return HistoryPoint{}, err
+	}
+	rewards, err := pq.Distribution_DelegationTotalRewards(address)
+	if err != nil {
+		return HistoryPoint{}, err
+	}
+	return HistoryPoint{
+		Height:      height,
+		Time:        blockTime,
+		Balances:    balances.Balances,
+		Delegations: delegations.DelegationResponses,
+		Rewards:     rewards.Total,
+	}, nil
+}
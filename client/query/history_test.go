@@ -0,0 +1,34 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryHeights(t *testing.T) {
+	heights, err := HistoryHeights(100, 350, 100)
+	require.NoError(t, err)
+	require.Equal(t, []int64{100, 200, 300, 350}, heights)
+
+	heights, err = HistoryHeights(100, 300, 100)
+	require.NoError(t, err)
+	require.Equal(t, []int64{100, 200, 300}, heights)
+
+	// Without a step, or with a step longer than the range, only the start and end are queried
+	heights, err = HistoryHeights(100, 300, 0)
+	require.NoError(t, err)
+	require.Equal(t, []int64{100, 300}, heights)
+	heights, err = HistoryHeights(100, 300, 1000)
+	require.NoError(t, err)
+	require.Equal(t, []int64{100, 300}, heights)
+
+	heights, err = HistoryHeights(100, 100, 10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{100}, heights)
+
+	_, err = HistoryHeights(300, 100, 10)
+	require.Error(t, err)
+	_, err = HistoryHeights(100, 300, -1)
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"github.com/cosmos/cosmos-sdk/types/query"
 	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
 	connectiontypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
 	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
@@ -9,7 +10,7 @@ import (
 // ibc_ParamsRPC returns the distribution params
 func ibc_ClientParamsRPC(q *Query) (*clienttypes.QueryClientParamsResponse, error) {
 	req := &clienttypes.QueryClientParamsRequest{}
-	queryClient := clienttypes.NewQueryClient(q.Client)
+	queryClient := clienttypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.ClientParams(ctx, req)
@@ -22,7 +23,7 @@ func ibc_ClientParamsRPC(q *Query) (*clienttypes.QueryClientParamsResponse, erro
 // ibc_ClientStateRPC returns the state of the specified IBC client.
 func ibc_ClientStateRPC(q *Query, clientId string) (*clienttypes.QueryClientStateResponse, error) {
 	req := &clienttypes.QueryClientStateRequest{ClientId: clientId}
-	queryClient := clienttypes.NewQueryClient(q.Client)
+	queryClient := clienttypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.ClientState(ctx, req)
@@ -34,15 +35,21 @@ func ibc_ClientStateRPC(q *Query, clientId string) (*clienttypes.QueryClientStat
 
 // ibc_ClientStatesRPC returns the state of the all IBC clients.
 func ibc_ClientStatesRPC(q *Query) (*clienttypes.QueryClientStatesResponse, error) {
-	req := &clienttypes.QueryClientStatesRequest{Pagination: q.Options.Pagination}
-	queryClient := clienttypes.NewQueryClient(q.Client)
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.ClientStates(ctx, req)
+	clientStates, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) (clienttypes.IdentifiedClientStates, *query.PageResponse, error) {
+		queryClient := clienttypes.NewQueryClient(q)
+		req := &clienttypes.QueryClientStatesRequest{Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.ClientStates(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.ClientStates, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &clienttypes.QueryClientStatesResponse{ClientStates: clientStates, Pagination: page}, nil
 }
 
 // ibc_ConsensusStateRPC returns the consensus state of the specified IBC client.
@@ -55,7 +62,7 @@ func ibc_ConsensusStateRPC(q *Query, clientId string, height clienttypes.Height)
 		req.RevisionHeight = height.RevisionHeight
 	}
 
-	queryClient := clienttypes.NewQueryClient(q.Client)
+	queryClient := clienttypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.ConsensusState(ctx, req)
@@ -67,23 +74,29 @@ func ibc_ConsensusStateRPC(q *Query, clientId string, height clienttypes.Height)
 
 // ibc_ConsensusStatesRPC returns the consensus states of given IBC client.
 func ibc_ConsensusStatesRPC(q *Query, clientId string) (*clienttypes.QueryConsensusStatesResponse, error) {
-	req := &clienttypes.QueryConsensusStatesRequest{ClientId: clientId, Pagination: q.Options.Pagination}
 
-	queryClient := clienttypes.NewQueryClient(q.Client)
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.ConsensusStates(ctx, req)
+	consensusStates, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]clienttypes.ConsensusStateWithHeight, *query.PageResponse, error) {
+		queryClient := clienttypes.NewQueryClient(q)
+		req := &clienttypes.QueryConsensusStatesRequest{ClientId: clientId, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.ConsensusStates(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.ConsensusStates, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &clienttypes.QueryConsensusStatesResponse{ConsensusStates: consensusStates, Pagination: page}, nil
 }
 
 // ibc_ConnectionRPC returns the state of the specified IBC connection.
 func ibc_ConnectionRPC(q *Query, connectionId string) (*connectiontypes.QueryConnectionResponse, error) {
 	req := &connectiontypes.QueryConnectionRequest{ConnectionId: connectionId}
 
-	queryClient := connectiontypes.NewQueryClient(q.Client)
+	queryClient := connectiontypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.Connection(ctx, req)
@@ -95,23 +108,31 @@ func ibc_ConnectionRPC(q *Query, connectionId string) (*connectiontypes.QueryCon
 
 // ibc_ConnectionsRPC returns the state of all IBC connections.
 func ibc_ConnectionsRPC(q *Query) (*connectiontypes.QueryConnectionsResponse, error) {
-	req := &connectiontypes.QueryConnectionsRequest{Pagination: q.Options.Pagination}
 
-	queryClient := connectiontypes.NewQueryClient(q.Client)
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.Connections(ctx, req)
+	var height clienttypes.Height
+	connections, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*connectiontypes.IdentifiedConnection, *query.PageResponse, error) {
+		queryClient := connectiontypes.NewQueryClient(q)
+		req := &connectiontypes.QueryConnectionsRequest{Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.Connections(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		height = res.Height
+		return res.Connections, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &connectiontypes.QueryConnectionsResponse{Connections: connections, Pagination: page, Height: height}, nil
 }
 
 // ibc_ChannelRPC returns the state of the specified IBC channel.
 func ibc_ChannelRPC(q *Query, channelId string, portId string) (*channeltypes.QueryChannelResponse, error) {
 	req := &channeltypes.QueryChannelRequest{PortId: portId, ChannelId: channelId}
 
-	queryClient := channeltypes.NewQueryClient(q.Client)
+	queryClient := channeltypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.Channel(ctx, req)
@@ -123,12 +144,170 @@ func ibc_ChannelRPC(q *Query, channelId string, portId string) (*channeltypes.Qu
 
 // ibc_ChannelsRPC returns the state of all IBC channels.
 func ibc_ChannelsRPC(q *Query) (*channeltypes.QueryChannelsResponse, error) {
-	req := &channeltypes.QueryChannelsRequest{Pagination: q.Options.Pagination}
 
-	queryClient := channeltypes.NewQueryClient(q.Client)
+	var height clienttypes.Height
+	channels, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*channeltypes.IdentifiedChannel, *query.PageResponse, error) {
+		queryClient := channeltypes.NewQueryClient(q)
+		req := &channeltypes.QueryChannelsRequest{Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.Channels(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		height = res.Height
+		return res.Channels, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &channeltypes.QueryChannelsResponse{Channels: channels, Pagination: page, Height: height}, nil
+}
+
+// ibc_PacketCommitmentRPC returns the commitment of a packet sent over the specified IBC channel.
+func ibc_PacketCommitmentRPC(q *Query, portId string, channelId string, sequence uint64) (*channeltypes.QueryPacketCommitmentResponse, error) {
+	req := &channeltypes.QueryPacketCommitmentRequest{PortId: portId, ChannelId: channelId, Sequence: sequence}
+
+	queryClient := channeltypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.PacketCommitment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ibc_PacketReceiptRPC returns whether a packet was received over the specified unordered IBC channel.
+func ibc_PacketReceiptRPC(q *Query, portId string, channelId string, sequence uint64) (*channeltypes.QueryPacketReceiptResponse, error) {
+	req := &channeltypes.QueryPacketReceiptRequest{PortId: portId, ChannelId: channelId, Sequence: sequence}
+
+	queryClient := channeltypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.PacketReceipt(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ibc_PacketAcknowledgementRPC returns the commitment of the acknowledgement of a packet received over the specified IBC channel.
+func ibc_PacketAcknowledgementRPC(q *Query, portId string, channelId string, sequence uint64) (*channeltypes.QueryPacketAcknowledgementResponse, error) {
+	req := &channeltypes.QueryPacketAcknowledgementRequest{PortId: portId, ChannelId: channelId, Sequence: sequence}
+
+	queryClient := channeltypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.PacketAcknowledgement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ibc_NextSequenceReceiveRPC returns the sequence of the next packet to be received over the specified ordered IBC channel.
+func ibc_NextSequenceReceiveRPC(q *Query, portId string, channelId string) (*channeltypes.QueryNextSequenceReceiveResponse, error) {
+	req := &channeltypes.QueryNextSequenceReceiveRequest{PortId: portId, ChannelId: channelId}
+
+	queryClient := channeltypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.NextSequenceReceive(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ibc_ConnectionChannelsRPC returns the state of the IBC channels of the specified connection.
+func ibc_ConnectionChannelsRPC(q *Query, connectionId string) (*channeltypes.QueryConnectionChannelsResponse, error) {
+
+	var height clienttypes.Height
+	channels, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*channeltypes.IdentifiedChannel, *query.PageResponse, error) {
+		queryClient := channeltypes.NewQueryClient(q)
+		req := &channeltypes.QueryConnectionChannelsRequest{Connection: connectionId, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.ConnectionChannels(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		height = res.Height
+		return res.Channels, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &channeltypes.QueryConnectionChannelsResponse{Channels: channels, Pagination: page, Height: height}, nil
+}
+
+// ibc_PacketCommitmentsRPC returns the commitments of the packets sent over the specified IBC channel.
+func ibc_PacketCommitmentsRPC(q *Query, portId string, channelId string) (*channeltypes.QueryPacketCommitmentsResponse, error) {
+
+	var height clienttypes.Height
+	commitments, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*channeltypes.PacketState, *query.PageResponse, error) {
+		queryClient := channeltypes.NewQueryClient(q)
+		req := &channeltypes.QueryPacketCommitmentsRequest{PortId: portId, ChannelId: channelId, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.PacketCommitments(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		height = res.Height
+		return res.Commitments, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &channeltypes.QueryPacketCommitmentsResponse{Commitments: commitments, Pagination: page, Height: height}, nil
+}
+
+// ibc_PacketAcknowledgementsRPC returns the acknowledgement commitments of the packets received over the specified IBC channel.
+func ibc_PacketAcknowledgementsRPC(q *Query, portId string, channelId string) (*channeltypes.QueryPacketAcknowledgementsResponse, error) {
+
+	var height clienttypes.Height
+	acknowledgements, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]*channeltypes.PacketState, *query.PageResponse, error) {
+		queryClient := channeltypes.NewQueryClient(q)
+		req := &channeltypes.QueryPacketAcknowledgementsRequest{PortId: portId, ChannelId: channelId, Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.PacketAcknowledgements(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		height = res.Height
+		return res.Acknowledgements, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &channeltypes.QueryPacketAcknowledgementsResponse{Acknowledgements: acknowledgements, Pagination: page, Height: height}, nil
+}
+
+// ibc_UnreceivedPacketsRPC returns which of the given packet sequences were not received over the specified IBC channel.
+func ibc_UnreceivedPacketsRPC(q *Query, portId string, channelId string, sequences []uint64) (*channeltypes.QueryUnreceivedPacketsResponse, error) {
+	req := &channeltypes.QueryUnreceivedPacketsRequest{PortId: portId, ChannelId: channelId, PacketCommitmentSequences: sequences}
+
+	queryClient := channeltypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.UnreceivedPackets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ibc_UnreceivedAcksRPC returns which of the given packet sequences sent over the specified IBC channel did not get their acknowledgement.
+func ibc_UnreceivedAcksRPC(q *Query, portId string, channelId string, sequences []uint64) (*channeltypes.QueryUnreceivedAcksResponse, error) {
+	req := &channeltypes.QueryUnreceivedAcksRequest{PortId: portId, ChannelId: channelId, PacketAckSequences: sequences}
+
+	queryClient := channeltypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
-	res, err := queryClient.Channels(ctx, req)
+	res, err := queryClient.UnreceivedAcks(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,272 @@
+package query
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/lens/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PacketState is where a packet is in its lifecycle.
+type PacketState string
+
+const (
+	// PacketPending is a packet that was neither received nor timed out yet.
+	PacketPending PacketState = "pending"
+	// PacketReceived is a packet received by the destination chain, whose
+	// acknowledgement was not relayed back to the source chain yet.
+	PacketReceived PacketState = "received"
+	// PacketAcknowledged is a packet whose acknowledgement was relayed back to the source chain.
+	PacketAcknowledged PacketState = "acknowledged"
+	// PacketTimedOut is a packet that was not received before its timeout. Once the
+	// timeout is relayed back, the source chain deletes the packet commitment.
+	PacketTimedOut PacketState = "timed-out"
+)
+
+// AckResult is the outcome of the acknowledgement of a packet.
+type AckResult string
+
+const (
+	// AckSuccess is a successful acknowledgement.
+	AckSuccess AckResult = "success"
+	// AckFailed is an error acknowledgement, the tokens of a transfer are refunded.
+	AckFailed AckResult = "error"
+	// AckUnknown is an acknowledgement whose content can't be read, because the
+	// destination node doesn't index txs or the acknowledgement is not a standard one.
+	AckUnknown AckResult = "unknown"
+)
+
+// PacketStatus is the state of a packet on its source and destination chains.
+type PacketStatus struct {
+	Packet channeltypes.Packet `json:"packet"`
+	State  PacketState         `json:"state"`
+	// Committed is whether the source chain still holds the packet commitment, which it
+	// deletes once the acknowledgement or timeout of the packet is relayed back.
+	Committed bool `json:"committed"`
+	// AckWritten is whether the destination chain holds the acknowledgement commitment of
+	// the packet, false for a received packet whose asynchronous acknowledgement is pending.
+	AckWritten bool `json:"ack_written"`
+	// AckResult is the outcome of the written acknowledgement, unset if none is written.
+	AckResult AckResult `json:"ack_result,omitempty"`
+	// Acknowledgement is the acknowledgement written by the destination chain, nil if
+	// the packet was not received or its write_acknowledgement event can't be found.
+	Acknowledgement *channeltypes.Acknowledgement `json:"acknowledgement,omitempty"`
+	// AckError is the error of an error acknowledgement.
+	AckError string `json:"ack_error,omitempty"`
+}
+
+// Ibc_TxPacketStatuses returns the status of each packet sent by the tx with hash txHash
+// on the chain of q. dst returns the query for the destination chain of a packet.
+func (q *Query) Ibc_TxPacketStatuses(txHash string, dst func(packet channeltypes.Packet) (*Query, error)) ([]PacketStatus, error) {
+	packets, err := q.Ibc_SentPackets(txHash)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]PacketStatus, len(packets))
+	for i, packet := range packets {
+		dstQuery, err := dst(packet)
+		if err != nil {
+			return nil, err
+		}
+		status, err := q.Ibc_PacketStatus(dstQuery, packet)
+		if err != nil {
+			return nil, err
+		}
+		statuses[i] = *status
+	}
+	return statuses, nil
+}
+
+// Ibc_SentPackets returns the packets sent by the tx with hash txHash, from its send_packet events.
+func (q *Query) Ibc_SentPackets(txHash string) ([]channeltypes.Packet, error) {
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	resTx, err := q.Client.QueryTx(ctx, txHash, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var packets []channeltypes.Packet
+	for _, event := range client.DecodeEvents(resTx.TxResult.Events) {
+		if event.Type != channeltypes.EventTypeSendPacket {
+			continue
+		}
+		packet, _, err := packetFromEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		packets = append(packets, packet)
+	}
+	if len(packets) == 0 {
+		return nil, fmt.Errorf("tx %s did not send any IBC packets", txHash)
+	}
+	return packets, nil
+}
+
+// Ibc_PacketStatus returns the status of packet, sent from the chain of q to the chain of dst.
+func (q *Query) Ibc_PacketStatus(dst *Query, packet channeltypes.Packet) (*PacketStatus, error) {
+	dstChannel, err := dst.Ibc_Channel(packet.DestinationChannel, packet.DestinationPort)
+	if err != nil {
+		return nil, err
+	}
+	if cp := dstChannel.Channel.Counterparty; cp.ChannelId != packet.SourceChannel || cp.PortId != packet.SourcePort {
+		return nil, fmt.Errorf("%s/%s on %s is not the counterparty of %s/%s", packet.DestinationPort, packet.DestinationChannel,
+			dst.Client.Config.ChainID, packet.SourcePort, packet.SourceChannel)
+	}
+
+	s := &PacketStatus{Packet: packet}
+	if _, err := q.Ibc_PacketCommitment(packet.SourcePort, packet.SourceChannel, packet.Sequence); err == nil {
+		s.Committed = true
+	} else if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	received, err := packetReceived(dst, packet, dstChannel.Channel.Ordering)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case received && s.Committed:
+		s.State = PacketReceived
+	case received:
+		s.State = PacketAcknowledged
+	case !s.Committed:
+		// The commitment is only deleted without a receipt when the timeout is relayed.
+		s.State = PacketTimedOut
+	default:
+		timedOut, err := packetTimedOut(dst, packet)
+		if err != nil {
+			return nil, err
+		}
+		s.State = PacketPending
+		if timedOut {
+			s.State = PacketTimedOut
+		}
+	}
+
+	if received {
+		// The acknowledgement commitment tells whether an acknowledgement was written, its
+		// content is only in the write_acknowledgement event of the receiving tx.
+		if _, err := dst.Ibc_PacketAcknowledgement(packet.DestinationPort, packet.DestinationChannel, packet.Sequence); err == nil {
+			s.AckWritten = true
+		} else if status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+		s.Acknowledgement = writtenAcknowledgement(dst, packet)
+		switch {
+		case s.Acknowledgement != nil && !s.Acknowledgement.Success():
+			s.AckWritten = true
+			s.AckResult = AckFailed
+			s.AckError = s.Acknowledgement.GetError()
+		case s.Acknowledgement != nil:
+			s.AckWritten = true
+			s.AckResult = AckSuccess
+		case s.AckWritten:
+			s.AckResult = AckUnknown
+		}
+	}
+	return s, nil
+}
+
+// packetReceived returns whether packet was received by the chain of dst.
+func packetReceived(dst *Query, packet channeltypes.Packet, ordering channeltypes.Order) (bool, error) {
+	if ordering == channeltypes.ORDERED {
+		res, err := dst.Ibc_NextSequenceReceive(packet.DestinationPort, packet.DestinationChannel)
+		if err != nil {
+			return false, err
+		}
+		return res.NextSequenceReceive > packet.Sequence, nil
+	}
+	res, err := dst.Ibc_PacketReceipt(packet.DestinationPort, packet.DestinationChannel, packet.Sequence)
+	if err != nil {
+		return false, err
+	}
+	return res.Received, nil
+}
+
+// packetTimedOut returns whether the latest block of the chain of dst is past the timeout of packet.
+func packetTimedOut(dst *Query, packet channeltypes.Packet) (bool, error) {
+	res, err := dst.Status()
+	if err != nil {
+		return false, err
+	}
+	height := clienttypes.NewHeight(clienttypes.ParseChainID(res.NodeInfo.Network), uint64(res.SyncInfo.LatestBlockHeight))
+	if !packet.TimeoutHeight.IsZero() && height.GTE(packet.TimeoutHeight) {
+		return true, nil
+	}
+	return packet.TimeoutTimestamp != 0 && uint64(res.SyncInfo.LatestBlockTime.UnixNano()) >= packet.TimeoutTimestamp, nil
+}
+
+// writtenAcknowledgement returns the acknowledgement of packet from the write_acknowledgement
+// event of the tx receiving it on the chain of dst. It returns nil if the node doesn't index
+// txs, or the acknowledgement is not a standard one.
+func writtenAcknowledgement(dst *Query, packet channeltypes.Packet) *channeltypes.Acknowledgement {
+	ctx, cancel := dst.GetQueryContext()
+	defer cancel()
+	txs, err := dst.Client.QueryTxs(ctx, 1, 1, []string{
+		fmt.Sprintf("%s.%s='%s'", channeltypes.EventTypeWriteAck, channeltypes.AttributeKeyDstPort, packet.DestinationPort),
+		fmt.Sprintf("%s.%s='%s'", channeltypes.EventTypeWriteAck, channeltypes.AttributeKeyDstChannel, packet.DestinationChannel),
+		fmt.Sprintf("%s.%s='%d'", channeltypes.EventTypeWriteAck, channeltypes.AttributeKeySequence, packet.Sequence),
+	})
+	if err != nil || len(txs) == 0 {
+		return nil
+	}
+
+	for _, event := range client.DecodeEvents(txs[0].TxResult.Events) {
+		if event.Type != channeltypes.EventTypeWriteAck {
+			continue
+		}
+		written, attrs, err := packetFromEvent(event)
+		if err != nil || written.DestinationChannel != packet.DestinationChannel || written.Sequence != packet.Sequence {
+			continue
+		}
+		bz, err := hex.DecodeString(attrs[channeltypes.AttributeKeyAckHex])
+		if err != nil {
+			return nil
+		}
+		var ack channeltypes.Acknowledgement
+		if err := dst.Client.Codec.Marshaler.UnmarshalJSON(bz, &ack); err != nil {
+			return nil
+		}
+		return &ack
+	}
+	return nil
+}
+
+// packetFromEvent decodes the packet of a send_packet or write_acknowledgement event, and
+// returns the attributes of the event by key.
+func packetFromEvent(event abci.Event) (channeltypes.Packet, map[string]string, error) {
+	attrs := make(map[string]string, len(event.Attributes))
+	for _, attr := range event.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+
+	var (
+		packet channeltypes.Packet
+		err    error
+	)
+	if packet.Sequence, err = strconv.ParseUint(attrs[channeltypes.AttributeKeySequence], 10, 64); err != nil {
+		return packet, nil, fmt.Errorf("invalid packet sequence in %s event: %w", event.Type, err)
+	}
+	if packet.Data, err = hex.DecodeString(attrs[channeltypes.AttributeKeyDataHex]); err != nil {
+		return packet, nil, fmt.Errorf("invalid packet data in %s event: %w", event.Type, err)
+	}
+	if packet.TimeoutHeight, err = clienttypes.ParseHeight(attrs[channeltypes.AttributeKeyTimeoutHeight]); err != nil {
+		return packet, nil, fmt.Errorf("invalid packet timeout height in %s event: %w", event.Type, err)
+	}
+	if packet.TimeoutTimestamp, err = strconv.ParseUint(attrs[channeltypes.AttributeKeyTimeoutTimestamp], 10, 64); err != nil {
+		return packet, nil, fmt.Errorf("invalid packet timeout timestamp in %s event: %w", event.Type, err)
+	}
+	packet.SourcePort = attrs[channeltypes.AttributeKeySrcPort]
+	packet.SourceChannel = attrs[channeltypes.AttributeKeySrcChannel]
+	packet.DestinationPort = attrs[channeltypes.AttributeKeyDstPort]
+	packet.DestinationChannel = attrs[channeltypes.AttributeKeyDstChannel]
+	return packet, attrs, nil
+}
@@ -0,0 +1,131 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gogoproto/proto"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPacketFromEvent(t *testing.T) {
+	event := abci.Event{
+		Type: channeltypes.EventTypeSendPacket,
+		Attributes: []abci.EventAttribute{
+			{Key: channeltypes.AttributeKeyDataHex, Value: "7b7d"},
+			{Key: channeltypes.AttributeKeyTimeoutHeight, Value: "1-1000"},
+			{Key: channeltypes.AttributeKeyTimeoutTimestamp, Value: "1700000000000000000"},
+			{Key: channeltypes.AttributeKeySequence, Value: "42"},
+			{Key: channeltypes.AttributeKeySrcPort, Value: "transfer"},
+			{Key: channeltypes.AttributeKeySrcChannel, Value: "channel-141"},
+			{Key: channeltypes.AttributeKeyDstPort, Value: "transfer"},
+			{Key: channeltypes.AttributeKeyDstChannel, Value: "channel-0"},
+			{Key: channeltypes.AttributeKeyChannelOrdering, Value: "ORDER_UNORDERED"},
+		},
+	}
+
+	packet, attrs, err := packetFromEvent(event)
+	require.NoError(t, err)
+	require.Equal(t, channeltypes.Packet{
+		Sequence:           42,
+		SourcePort:         "transfer",
+		SourceChannel:      "channel-141",
+		DestinationPort:    "transfer",
+		DestinationChannel: "channel-0",
+		Data:               []byte("{}"),
+		TimeoutHeight:      clienttypes.NewHeight(1, 1000),
+		TimeoutTimestamp:   1700000000000000000,
+	}, packet)
+	require.Equal(t, "ORDER_UNORDERED", attrs[channeltypes.AttributeKeyChannelOrdering])
+
+	event.Attributes[3].Value = "not a sequence"
+	_, _, err = packetFromEvent(event)
+	require.ErrorContains(t, err, "invalid packet sequence")
+}
+
+// mockRPCQuery makes mc answer the ABCI queries of the gRPC method path with res.
+func mockRPCQuery(t *testing.T, mc *mocks.Client, path string, res proto.Message) {
+	bz, err := proto.Marshal(res)
+	require.NoError(t, err)
+	mc.On("ABCIQueryWithOptions", mock.Anything, path, mock.Anything, mock.Anything).
+		Return(&coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: 100}}, nil)
+}
+
+// mockRPCQueryNotFound makes mc answer the ABCI queries of the gRPC method path with a not found error.
+func mockRPCQueryNotFound(mc *mocks.Client, path string) {
+	mc.On("ABCIQueryWithOptions", mock.Anything, path, mock.Anything, mock.Anything).
+		Return(&coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Code: sdkerrors.ErrKeyNotFound.ABCICode(), Log: "not found"}}, nil)
+}
+
+// newMockRPCQuery returns a query over the RPC client mc.
+func newMockRPCQuery(t *testing.T, mc *mocks.Client) *Query {
+	homepath := t.TempDir()
+	cfg := client.GetCosmosHubConfig(homepath, true)
+	cl, err := client.NewChainClient(zaptest.NewLogger(t), cfg, homepath, nil, nil)
+	require.NoError(t, err)
+	cl.RPCClient = mc
+	return &Query{Client: cl, Options: DefaultOptions()}
+}
+
+func TestPacketStatus_AckResultUnknown(t *testing.T) {
+	packet := channeltypes.Packet{
+		Sequence:           7,
+		SourcePort:         "transfer",
+		SourceChannel:      "channel-0",
+		DestinationPort:    "transfer",
+		DestinationChannel: "channel-1",
+		TimeoutTimestamp:   1700000000000000000,
+	}
+
+	// The same node serves as source and destination, the queries are on distinct channels
+	mc := new(mocks.Client)
+	mockRPCQuery(t, mc, "/ibc.core.channel.v1.Query/Channel", &channeltypes.QueryChannelResponse{
+		Channel: &channeltypes.Channel{
+			State:        channeltypes.OPEN,
+			Ordering:     channeltypes.UNORDERED,
+			Counterparty: channeltypes.NewCounterparty("transfer", "channel-0"),
+		},
+	})
+	mockRPCQuery(t, mc, "/ibc.core.channel.v1.Query/PacketCommitment", &channeltypes.QueryPacketCommitmentResponse{Commitment: []byte{1}})
+	mockRPCQuery(t, mc, "/ibc.core.channel.v1.Query/PacketReceipt", &channeltypes.QueryPacketReceiptResponse{Received: true})
+	mockRPCQuery(t, mc, "/ibc.core.channel.v1.Query/PacketAcknowledgement", &channeltypes.QueryPacketAcknowledgementResponse{Acknowledgement: []byte{1}})
+	// A node without tx indexing
+	mc.On("TxSearch", mock.Anything, mock.Anything, true, mock.Anything, mock.Anything, "").
+		Return(nil, errors.New("transaction indexing is disabled"))
+	q := newMockRPCQuery(t, mc)
+
+	s, err := q.Ibc_PacketStatus(q, packet)
+	require.NoError(t, err)
+	require.Equal(t, PacketReceived, s.State)
+	require.True(t, s.Committed)
+	require.True(t, s.AckWritten)
+	require.Equal(t, AckUnknown, s.AckResult)
+	require.Nil(t, s.Acknowledgement)
+
+	// Without the acknowledgement commitment, the acknowledgement is not written yet
+	mc = new(mocks.Client)
+	mockRPCQuery(t, mc, "/ibc.core.channel.v1.Query/Channel", &channeltypes.QueryChannelResponse{
+		Channel: &channeltypes.Channel{Counterparty: channeltypes.NewCounterparty("transfer", "channel-0")},
+	})
+	mockRPCQuery(t, mc, "/ibc.core.channel.v1.Query/PacketCommitment", &channeltypes.QueryPacketCommitmentResponse{Commitment: []byte{1}})
+	mockRPCQuery(t, mc, "/ibc.core.channel.v1.Query/PacketReceipt", &channeltypes.QueryPacketReceiptResponse{Received: true})
+	mockRPCQueryNotFound(mc, "/ibc.core.channel.v1.Query/PacketAcknowledgement")
+	mc.On("TxSearch", mock.Anything, mock.Anything, true, mock.Anything, mock.Anything, "").
+		Return(&coretypes.ResultTxSearch{}, nil)
+	q = newMockRPCQuery(t, mc)
+
+	s, err = q.Ibc_PacketStatus(q, packet)
+	require.NoError(t, err)
+	require.Equal(t, PacketReceived, s.State)
+	require.False(t, s.AckWritten)
+	require.Empty(t, s.AckResult)
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/gogoproto/proto"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRPCRequest makes mc answer the ABCI queries of the gRPC method path whose request,
+// decoded into req, matches with res.
+func mockRPCRequest[T proto.Message](t *testing.T, mc *mocks.Client, path string, req T, match func(T) bool, res proto.Message) {
+	bz, err := proto.Marshal(res)
+	require.NoError(t, err)
+	matches := mock.MatchedBy(func(data bytes.HexBytes) bool {
+		return proto.Unmarshal(data, req) == nil && match(req)
+	})
+	mc.On("ABCIQueryWithOptions", mock.Anything, path, matches, mock.Anything).
+		Return(&coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: 100}}, nil)
+}
+
+func TestConnectionChannels_FollowsPages(t *testing.T) {
+	mc := new(mocks.Client)
+	path := "/ibc.core.channel.v1.Query/ConnectionChannels"
+	firstPage := func(req *channeltypes.QueryConnectionChannelsRequest) bool {
+		return req.Connection == "connection-0" && len(req.Pagination.Key) == 0
+	}
+	mockRPCRequest(t, mc, path, &channeltypes.QueryConnectionChannelsRequest{}, firstPage, &channeltypes.QueryConnectionChannelsResponse{
+		Channels:   []*channeltypes.IdentifiedChannel{{ChannelId: "channel-0", PortId: "transfer"}},
+		Pagination: &query.PageResponse{NextKey: []byte("next")},
+	})
+	secondPage := func(req *channeltypes.QueryConnectionChannelsRequest) bool {
+		return req.Connection == "connection-0" && string(req.Pagination.Key) == "next"
+	}
+	mockRPCRequest(t, mc, path, &channeltypes.QueryConnectionChannelsRequest{}, secondPage, &channeltypes.QueryConnectionChannelsResponse{
+		Channels:   []*channeltypes.IdentifiedChannel{{ChannelId: "channel-1", PortId: "icahost"}},
+		Pagination: &query.PageResponse{},
+	})
+	q := newMockRPCQuery(t, mc)
+	q.Options.MaxItems = 0
+
+	res, err := q.Ibc_ConnectionChannels("connection-0")
+	require.NoError(t, err)
+	require.Len(t, res.Channels, 2)
+	require.Equal(t, "channel-0", res.Channels[0].ChannelId)
+	require.Equal(t, "channel-1", res.Channels[1].ChannelId)
+	mc.AssertNumberOfCalls(t, "ABCIQueryWithOptions", 2)
+}
+
+func TestUnreceivedPackets(t *testing.T) {
+	mc := new(mocks.Client)
+	match := func(req *channeltypes.QueryUnreceivedPacketsRequest) bool {
+		return req.PortId == "transfer" && req.ChannelId == "channel-0" && len(req.PacketCommitmentSequences) == 3
+	}
+	mockRPCRequest(t, mc, "/ibc.core.channel.v1.Query/UnreceivedPackets", &channeltypes.QueryUnreceivedPacketsRequest{}, match,
+		&channeltypes.QueryUnreceivedPacketsResponse{Sequences: []uint64{2}})
+	q := newMockRPCQuery(t, mc)
+
+	res, err := q.Ibc_UnreceivedPackets("transfer", "channel-0", []uint64{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, res.Sequences)
+}
+
+func TestDenomTrace_TrimsPrefix(t *testing.T) {
+	hash := "27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	mc := new(mocks.Client)
+	// Chains before ibc-go v7 only accept the hash without the ibc/ prefix
+	match := func(req *transfertypes.QueryDenomTraceRequest) bool { return req.Hash == hash }
+	mockRPCRequest(t, mc, "/ibc.applications.transfer.v1.Query/DenomTrace", &transfertypes.QueryDenomTraceRequest{}, match,
+		&transfertypes.QueryDenomTraceResponse{DenomTrace: &transfertypes.DenomTrace{Path: "transfer/channel-141", BaseDenom: "uosmo"}})
+	q := newMockRPCQuery(t, mc)
+
+	for _, denom := range []string{hash, "ibc/" + hash} {
+		res, err := q.Transfer_DenomTrace(denom)
+		require.NoError(t, err)
+		require.Equal(t, "uosmo", res.DenomTrace.BaseDenom)
+		require.Equal(t, "transfer/channel-141", res.DenomTrace.Path)
+	}
+}
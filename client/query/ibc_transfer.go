@@ -0,0 +1,25 @@
+package query
+
+import (
+	"time"
+
+	"github.com/cosmos/ibc-go/v7/modules/core/exported"
+	"github.com/strangelove-ventures/lens/client"
+)
+
+// Ibc_CounterpartyClientState returns the id and state of the client tracking the
+// counterparty chain of the channel on portId.
+func (q *Query) Ibc_CounterpartyClientState(portId, channelId string) (string, exported.ClientState, error) {
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	return client.CounterpartyClientState(ctx, q, portId, channelId)
+}
+
+// Ibc_TransferTimeout returns the timeout of a packet sent over the channel on portId,
+// heightOffset blocks and timeOffset after the latest counterparty height and time known
+// to this chain. A zero offset disables that timeout.
+func (q *Query) Ibc_TransferTimeout(portId, channelId string, heightOffset uint64, timeOffset time.Duration) (client.IBCTimeout, error) {
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	return client.TransferTimeout(ctx, q, portId, channelId, heightOffset, timeOffset)
+}
@@ -0,0 +1,21 @@
+package query
+
+import (
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/strangelove-ventures/lens/client/pagination"
+)
+
+// Paginate collects the items of a list query with the pagination of the options of q,
+// see pagination.Collect. fetch is called with the page request of each page and a copy
+// of q to send it with. If the options have no height, the copy pins the height of the
+// first reply, so that all the pages come from the same block.
+func Paginate[S ~[]T, T any](q *Query, fetch func(*Query, *query.PageRequest) (S, *query.PageResponse, error)) (S, *query.PageResponse, error) {
+	opts := QueryOptions{}
+	if q.Options != nil {
+		opts = *q.Options
+	}
+	pinned := &Query{Client: q.Client, Options: &opts, ctx: q.ctx, pinHeight: true}
+	return pagination.Collect(opts.Pagination, opts.MaxItems, func(pr *query.PageRequest) (S, *query.PageResponse, error) {
+		return fetch(pinned, pr)
+	})
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate_PinsHeight(t *testing.T) {
+	mc := new(mocks.Client)
+	path := "/cosmos.bank.v1beta1.Query/TotalSupply"
+	page := func(height int64, res *bankTypes.QueryTotalSupplyResponse) {
+		bz, err := proto.Marshal(res)
+		require.NoError(t, err)
+		atHeight := mock.MatchedBy(func(opts rpcclient.ABCIQueryOptions) bool { return opts.Height == height })
+		mc.On("ABCIQueryWithOptions", mock.Anything, path, mock.Anything, atHeight).
+			Return(&coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: 100}}, nil).Once()
+	}
+	// The first page is read at the latest height, the next one at the height of the first
+	page(0, &bankTypes.QueryTotalSupplyResponse{
+		Supply:     sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)),
+		Pagination: &query.PageResponse{NextKey: []byte("next")},
+	})
+	page(100, &bankTypes.QueryTotalSupplyResponse{
+		Supply:     sdk.NewCoins(sdk.NewInt64Coin("uosmo", 2)),
+		Pagination: &query.PageResponse{},
+	})
+	q := newMockRPCQuery(t, mc)
+
+	res, err := q.Bank_TotalSupply()
+	require.NoError(t, err)
+	require.Len(t, res.Supply, 2)
+	mc.AssertExpectations(t)
+	require.Zero(t, q.Options.Height, "the options of the query are left as they are")
+}
@@ -1,9 +1,15 @@
 package query
 
 import (
+	"context"
+
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	distributionTypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	govTypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
 	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
 	connectiontypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
 	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
@@ -14,49 +20,48 @@ import (
 type Query struct {
 	Client  *client.ChainClient
 	Options *QueryOptions
+
+	// ctx is the parent of the contexts of the queries, context.Background if nil.
+	ctx context.Context
+	// pinHeight makes a query without a height pin the height of its first reply, it is
+	// set on the copy Paginate sends the pages of a list query with.
+	pinHeight bool
 }
 
 // Bank queries
 
 // Return params for bank module.
 func (q *Query) Bank_Params() (*bankTypes.QueryParamsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return bank_ParamsRPC(q)
 }
 
 // Balances returns the balance of specific denom for a single account.
 func (q *Query) Bank_Balance(address string, denom string) (*bankTypes.QueryBalanceResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return bank_BalanceRPC(q, address, denom)
 }
 
 // Balances returns the balance of all coins for a single account.
 func (q *Query) Bank_Balances(address string) (*bankTypes.QueryAllBalancesResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return bank_AllBalancesRPC(q, address)
 }
 
 // SupplyOf returns the supply of given coin
 func (q *Query) Bank_SupplyOf(denom string) (*bankTypes.QuerySupplyOfResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return bank_SupplyOfRPC(q, denom)
 }
 
 // TotalSupply returns the supply of all coins
 func (q *Query) Bank_TotalSupply() (*bankTypes.QueryTotalSupplyResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return bank_TotalSupplyRPC(q)
 }
 
 // DenomMetadata returns the metadata for given denoms
 func (q *Query) Bank_DenomMetadata(denom string) (*bankTypes.QueryDenomMetadataResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return bank_DenomMetadataRPC(q, denom)
 }
 
 // DenomsMetadata returns the metadata for all denoms
 func (q *Query) Bank_DenomsMetadata() (*bankTypes.QueryDenomsMetadataResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return bank_DenomsMetadataRPC(q)
 }
 
@@ -64,85 +69,71 @@ func (q *Query) Bank_DenomsMetadata() (*bankTypes.QueryDenomsMetadataResponse, e
 
 // Return params for staking module.
 func (q *Query) Staking_Params() (*stakingTypes.QueryParamsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_ParamsRPC(q)
 }
 
 // Return balance of staking pool.
 func (q *Query) Staking_Pool() (*stakingTypes.QueryPoolResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_PoolRPC(q)
 }
 
 // Return specified validator.
 func (q *Query) Staking_Validator(address string) (*stakingTypes.QueryValidatorResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_ValidatorRPC(q, address)
 }
 
 // Return validators for given status.
 func (q *Query) Staking_Validators(status string) (*stakingTypes.QueryValidatorsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_ValidatorsRPC(q, status)
 }
 
 // ValidatorDelegations returns all the delegations for a validator
 func (q *Query) Staking_ValidatorDelegations(validator string) (*stakingTypes.QueryValidatorDelegationsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_ValidatorDelegationsRPC(q, validator)
 }
 
 // ValidatorDelegations returns all the unbonding delegations for a validator
 func (q *Query) Staking_ValidatorUnbondingDelegations(validator string) (*stakingTypes.QueryValidatorUnbondingDelegationsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_ValidatorUnbondingDelegationsRPC(q, validator)
 }
 
 // Delegation returns the delegations for a particular validator / delegator tuple
 func (q *Query) Staking_Delegation(delegator string, validator string) (*stakingTypes.QueryDelegationResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_DelegationRPC(q, delegator, validator)
 }
 
 // UnbondingDelegation returns the unbonding delegations for a particular validator / delegator tuple
 func (q *Query) Staking_UnbondingDelegation(delegator string, validator string) (*stakingTypes.QueryUnbondingDelegationResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_UnbondingDelegationRPC(q, delegator, validator)
 }
 
 // DelegatorDelegations returns all the delegations for a given delegator
 func (q *Query) Staking_DelegatorDelegations(delegator string) (*stakingTypes.QueryDelegatorDelegationsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_DelegatorDelegationsRPC(q, delegator)
 }
 
 // Delegations returns all the unbonding delegations for a given delegator
 func (q *Query) Staking_DelegatorUnbondingDelegations(delegator string) (*stakingTypes.QueryDelegatorUnbondingDelegationsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_DelegatorUnbondingDelegationsRPC(q, delegator)
 }
 
 // Delegation returns the delegations for a particular validator / delegator tuple
 func (q *Query) Staking_Redelegations(delegator string, src_validator string, dst_validator string) (*stakingTypes.QueryRedelegationsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_RedelegationsRPC(q, delegator, src_validator, dst_validator)
 }
 
 // DelegatorValidators returns all the validators for a given delegator
 func (q *Query) Staking_DelegatorValidators(delegator string) (*stakingTypes.QueryDelegatorValidatorsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_DelegatorValidatorsRPC(q, delegator)
 }
 
 // DelegatorValidators returns the validator for a given delegator / validator tuple
 func (q *Query) Staking_DelegatorValidator(delegator string, validator string) (*stakingTypes.QueryDelegatorValidatorResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_DelegatorValidatorRPC(q, delegator, validator)
 }
 
 // HistoricalInfoRPC return histrical info for a given height
 func (q *Query) Staking_HistoricalInfo(height int64) (*stakingTypes.QueryHistoricalInfoResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return staking_HistoricalInfoRPC(q, height)
 }
 
@@ -150,93 +141,155 @@ func (q *Query) Staking_HistoricalInfo(height int64) (*stakingTypes.QueryHistori
 
 // Return params for staking module.
 func (q *Query) Distribution_Params() (*distributionTypes.QueryParamsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_ParamsRPC(q)
 }
 
 // Return balance of community pool.
 func (q *Query) Distribution_CommunityPool() (*distributionTypes.QueryCommunityPoolResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_CommunityPoolRPC(q)
 }
 
 // ValidatorOutstandingRewards returns the outstanding reward pool for given validator
 func (q *Query) Distribution_ValidatorOutstandingRewards(validator string) (*distributionTypes.QueryValidatorOutstandingRewardsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_ValidatorOutstandingRewardsRPC(q, validator)
 }
 
 // ValidatorCommission returns the outstanding commission for given validator
 func (q *Query) Distribution_ValidatorCommission(validator string) (*distributionTypes.QueryValidatorCommissionResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_ValidatorCommissionRPC(q, validator)
 }
 
 // ValidatorSlashes returns slashing events for given validator between the optional start and end height
 func (q *Query) Distribution_ValidatorSlashes(validator string, start uint64, end uint64) (*distributionTypes.QueryValidatorSlashesResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_ValidatorSlashesRPC(q, validator, start, end)
 }
 
 // DelegationRewards returns the validators of a delegator
 func (q *Query) Distribution_DelegationRewards(delegator string, validator string) (*distributionTypes.QueryDelegationRewardsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_DelegationRewardsRPC(q, delegator, validator)
 }
 
 // DelegationTotalRewards returns the validators of a delegator
 func (q *Query) Distribution_DelegationTotalRewards(delegator string) (*distributionTypes.QueryDelegationTotalRewardsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_DelegationTotalRewardsRPC(q, delegator)
 }
 
 // DelegatorValidators returns the validators of a delegator
 func (q *Query) Distribution_DelegatorValidators(delegator string) (*distributionTypes.QueryDelegatorValidatorsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_DelegatorValidatorsRPC(q, delegator)
 }
 
 // DelegatorWithdrawAddress returns the validators of a delegator
 func (q *Query) Distribution_DelegatorWithdrawAddress(delegator string) (*distributionTypes.QueryDelegatorWithdrawAddressResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return distribution_DelegatorWithdrawAddressRPC(q, delegator)
 }
 
+// Feegrant queries
+
+// Feegrant_Allowance returns the fee allowance granted by granter to grantee
+func (q *Query) Feegrant_Allowance(granter string, grantee string) (*feegrant.QueryAllowanceResponse, error) {
+	return feegrant_AllowanceRPC(q, granter, grantee)
+}
+
+// Feegrant_Allowances returns all the fee allowances granted to grantee
+func (q *Query) Feegrant_Allowances(grantee string) (*feegrant.QueryAllowancesResponse, error) {
+	return feegrant_AllowancesRPC(q, grantee)
+}
+
+// Feegrant_AllowancesByGranter returns all the fee allowances granted by granter
+func (q *Query) Feegrant_AllowancesByGranter(granter string) (*feegrant.QueryAllowancesByGranterResponse, error) {
+	return feegrant_AllowancesByGranterRPC(q, granter)
+}
+
+// Gov queries
+
+// Gov_Proposal returns the proposal with the given id
+func (q *Query) Gov_Proposal(id uint64) (*govTypes.QueryProposalResponse, error) {
+	return gov_ProposalRPC(q, id)
+}
+
+// Gov_Proposals returns the proposals with the given status, voted on by voter and deposited on by depositor.
+// Empty filters match all proposals.
+func (q *Query) Gov_Proposals(status govTypes.ProposalStatus, voter string, depositor string) (*govTypes.QueryProposalsResponse, error) {
+	return gov_ProposalsRPC(q, status, voter, depositor)
+}
+
+// Gov_Vote returns the vote of voter on a proposal
+func (q *Query) Gov_Vote(id uint64, voter string) (*govTypes.QueryVoteResponse, error) {
+	return gov_VoteRPC(q, id, voter)
+}
+
+// Gov_Votes returns the votes on a proposal
+func (q *Query) Gov_Votes(id uint64) (*govTypes.QueryVotesResponse, error) {
+	return gov_VotesRPC(q, id)
+}
+
+// Gov_Deposit returns the deposit of depositor on a proposal
+func (q *Query) Gov_Deposit(id uint64, depositor string) (*govTypes.QueryDepositResponse, error) {
+	return gov_DepositRPC(q, id, depositor)
+}
+
+// Gov_Deposits returns the deposits on a proposal
+func (q *Query) Gov_Deposits(id uint64) (*govTypes.QueryDepositsResponse, error) {
+	return gov_DepositsRPC(q, id)
+}
+
+// Gov_TallyResult returns the tally of the votes on a proposal
+func (q *Query) Gov_TallyResult(id uint64) (*govTypes.QueryTallyResultResponse, error) {
+	return gov_TallyResultRPC(q, id)
+}
+
+// Gov_Params returns the gov params of the given type, one of deposit, voting or tallying
+func (q *Query) Gov_Params(paramsType string) (*govTypes.QueryParamsResponse, error) {
+	return gov_ParamsRPC(q, paramsType)
+}
+
+// Slashing queries
+
+// Slashing_Params returns the slashing params
+func (q *Query) Slashing_Params() (*slashingTypes.QueryParamsResponse, error) {
+	return slashing_ParamsRPC(q)
+}
+
+// Slashing_SigningInfo returns the signing info of the validator with the given consensus (valcons) address
+func (q *Query) Slashing_SigningInfo(consAddress string) (*slashingTypes.QuerySigningInfoResponse, error) {
+	return slashing_SigningInfoRPC(q, consAddress)
+}
+
+// Slashing_SigningInfos returns the signing infos of all validators
+func (q *Query) Slashing_SigningInfos() (*slashingTypes.QuerySigningInfosResponse, error) {
+	return slashing_SigningInfosRPC(q)
+}
+
 // Tendermint queries
 
 // Block returns information about a block
 func (q *Query) Block() (*coretypes.ResultBlock, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return BlockRPC(q)
 }
 
 // BlockByHash returns information about a block by hash
 func (q *Query) BlockByHash(hash string) (*coretypes.ResultBlock, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return BlockByHashRPC(q, hash)
 }
 
 // BlockResults returns information about a block by hash
 func (q *Query) BlockResults() (*coretypes.ResultBlockResults, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return BlockResultsRPC(q)
 }
 
 // Status returns information about a node status
 func (q *Query) Status() (*coretypes.ResultStatus, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return StatusRPC(q)
 }
 
 // ABCIInfo returns general information about the ABCI application
 func (q *Query) ABCIInfo() (*coretypes.ResultABCIInfo, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ABCIInfoRPC(q)
 }
 
 // ABCIQuery returns data from a particular path in the ABCI application
 func (q *Query) ABCIQuery(path string, data string, prove bool) (*coretypes.ResultABCIQuery, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ABCIQueryRPC(q, path, data, prove)
 }
 
@@ -244,54 +297,105 @@ func (q *Query) ABCIQuery(path string, data string, prove bool) (*coretypes.Resu
 
 // IBCQuery returns parameters for the IBC client submodule.
 func (q *Query) Ibc_ClientParams() (*clienttypes.QueryClientParamsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ClientParamsRPC(q)
 }
 
 // Ibc_ClientState returns the client state for the specified IBC client.
 func (q *Query) Ibc_ClientState(clientId string) (*clienttypes.QueryClientStateResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ClientStateRPC(q, clientId)
 }
 
 // Ibc_ClientStates returns the client state for all IBC clients.
 func (q *Query) Ibc_ClientStates() (*clienttypes.QueryClientStatesResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ClientStatesRPC(q)
 }
 
 // Ibc_ConsensusState returns the consensus state for the specified IBC client and the given height.
 func (q *Query) Ibc_ConsensusState(clientId string, height clienttypes.Height) (*clienttypes.QueryConsensusStateResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ConsensusStateRPC(q, clientId, height)
 }
 
 // Ibc_ConsensusState returns all consensus states for the specified IBC client.
 func (q *Query) Ibc_ConsensusStates(clientId string) (*clienttypes.QueryConsensusStatesResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ConsensusStatesRPC(q, clientId)
 }
 
 // Ibc_Connection returns the connection state for the specified IBC connection.
 func (q *Query) Ibc_Connection(connectionId string) (*connectiontypes.QueryConnectionResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ConnectionRPC(q, connectionId)
 }
 
 // Ibc_Connections returns the connection state for all IBC connections.
 func (q *Query) Ibc_Connections() (*connectiontypes.QueryConnectionsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ConnectionsRPC(q)
 }
 
 // Ibc_Channel returns the channel state for the specified IBC channel and port.
 func (q *Query) Ibc_Channel(channelId string, portId string) (*channeltypes.QueryChannelResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ChannelRPC(q, channelId, portId)
 }
 
 // Ibc_Channels returns the channel state for all IBC channels.
 func (q *Query) Ibc_Channels() (*channeltypes.QueryChannelsResponse, error) {
-	/// TODO: In the future have some logic to route the query to the appropriate client (gRPC or RPC)
 	return ibc_ChannelsRPC(q)
 }
+
+// Ibc_PacketCommitment returns the commitment of a packet sent over the specified IBC channel and port.
+func (q *Query) Ibc_PacketCommitment(portId string, channelId string, sequence uint64) (*channeltypes.QueryPacketCommitmentResponse, error) {
+	return ibc_PacketCommitmentRPC(q, portId, channelId, sequence)
+}
+
+// Ibc_PacketReceipt returns whether a packet was received over the specified unordered IBC channel and port.
+func (q *Query) Ibc_PacketReceipt(portId string, channelId string, sequence uint64) (*channeltypes.QueryPacketReceiptResponse, error) {
+	return ibc_PacketReceiptRPC(q, portId, channelId, sequence)
+}
+
+// Ibc_PacketAcknowledgement returns the acknowledgement commitment of a packet received over the specified IBC channel and port.
+func (q *Query) Ibc_PacketAcknowledgement(portId string, channelId string, sequence uint64) (*channeltypes.QueryPacketAcknowledgementResponse, error) {
+	return ibc_PacketAcknowledgementRPC(q, portId, channelId, sequence)
+}
+
+// Ibc_NextSequenceReceive returns the sequence of the next packet to be received over the specified ordered IBC channel and port.
+func (q *Query) Ibc_NextSequenceReceive(portId string, channelId string) (*channeltypes.QueryNextSequenceReceiveResponse, error) {
+	return ibc_NextSequenceReceiveRPC(q, portId, channelId)
+}
+
+// Ibc_ConnectionChannels returns the channel state for all IBC channels of the specified connection.
+func (q *Query) Ibc_ConnectionChannels(connectionId string) (*channeltypes.QueryConnectionChannelsResponse, error) {
+	return ibc_ConnectionChannelsRPC(q, connectionId)
+}
+
+// Ibc_PacketCommitments returns the commitments of all packets sent over the specified IBC channel and port
+// that were not acknowledged or timed out yet.
+func (q *Query) Ibc_PacketCommitments(portId string, channelId string) (*channeltypes.QueryPacketCommitmentsResponse, error) {
+	return ibc_PacketCommitmentsRPC(q, portId, channelId)
+}
+
+// Ibc_PacketAcknowledgements returns the acknowledgement commitments of all packets received over the specified IBC channel and port.
+func (q *Query) Ibc_PacketAcknowledgements(portId string, channelId string) (*channeltypes.QueryPacketAcknowledgementsResponse, error) {
+	return ibc_PacketAcknowledgementsRPC(q, portId, channelId)
+}
+
+// Ibc_UnreceivedPackets returns which of the packet sequences committed on the counterparty chain were not
+// received over the specified IBC channel and port.
+func (q *Query) Ibc_UnreceivedPackets(portId string, channelId string, sequences []uint64) (*channeltypes.QueryUnreceivedPacketsResponse, error) {
+	return ibc_UnreceivedPacketsRPC(q, portId, channelId, sequences)
+}
+
+// Ibc_UnreceivedAcks returns which of the packet sequences acknowledged on the counterparty chain did not
+// get their acknowledgement relayed back over the specified IBC channel and port.
+func (q *Query) Ibc_UnreceivedAcks(portId string, channelId string, sequences []uint64) (*channeltypes.QueryUnreceivedAcksResponse, error) {
+	return ibc_UnreceivedAcksRPC(q, portId, channelId, sequences)
+}
+
+// IBC transfer queries
+
+// Transfer_DenomTrace returns the denom trace of an ibc/ denom or its hash.
+func (q *Query) Transfer_DenomTrace(hash string) (*transfertypes.QueryDenomTraceResponse, error) {
+	return transfer_DenomTraceRPC(q, hash)
+}
+
+// Transfer_EscrowAddress returns the address escrowing the tokens sent over the specified IBC channel and port.
+func (q *Query) Transfer_EscrowAddress(portId string, channelId string) (*transfertypes.QueryEscrowAddressResponse, error) {
+	return transfer_EscrowAddressRPC(q, portId, channelId)
+}
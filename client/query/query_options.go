@@ -10,8 +10,14 @@ import (
 )
 
 type QueryOptions struct {
+	// Pagination is the first page request of list queries, its limit is the page size.
 	Pagination *query.PageRequest
 	Height     int64
+	// MaxItems is the maximum number of items list queries collect by following the
+	// next key of each page, 0 collects all of them.
+	MaxItems uint64
+	// Route decides whether queries are sent over gRPC or RPC, RouteRPC if it is empty.
+	Route Route
 }
 
 func DefaultOptions() *QueryOptions {
@@ -29,7 +35,11 @@ func DefaultOptions() *QueryOptions {
 // GetQueryContext returns a context that includes the height and uses the timeout from the config
 func (q *Query) GetQueryContext() (context.Context, context.CancelFunc) {
 	timeout, _ := time.ParseDuration(q.Client.Config.Timeout) // Timeout is validated in the config so no error check
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	parent := q.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	strHeight := strconv.Itoa(int(q.Options.Height))
 	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strHeight)
 	return ctx, cancel
@@ -0,0 +1,131 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/strangelove-ventures/lens/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Route is the policy deciding whether queries go to the gRPC server of a node or
+// are sent as ABCI queries over RPC.
+type Route string
+
+const (
+	// RouteRPC sends queries as ABCI queries over RPC, it is the default.
+	RouteRPC Route = "rpc"
+	// RoutePreferGRPC sends queries over gRPC if the chain has a gRPC address, and falls
+	// back to RPC if it hasn't or the gRPC server is unavailable or doesn't serve the query.
+	RoutePreferGRPC Route = "prefer-grpc"
+	// RouteGRPC only sends queries over gRPC.
+	RouteGRPC Route = "grpc"
+)
+
+// ParseRoute parses a query route, the empty string is RouteRPC.
+func ParseRoute(s string) (Route, error) {
+	switch r := Route(s); r {
+	case "":
+		return RouteRPC, nil
+	case RouteRPC, RoutePreferGRPC, RouteGRPC:
+		return r, nil
+	default:
+		return "", fmt.Errorf("invalid query route %q, expected %s, %s or %s", s, RouteRPC, RoutePreferGRPC, RouteGRPC)
+	}
+}
+
+var _ gogogrpc.ClientConn = &Query{}
+
+// Invoke implements the grpc ClientConn.Invoke method, it sends the query over gRPC or RPC
+// according to the route of the query options.
+func (q *Query) Invoke(ctx context.Context, method string, req, reply interface{}, opts ...grpc.CallOption) error {
+	if q.pinHeight {
+		if height := q.height(); height > 0 {
+			// The context may not come from GetQueryContext and miss the height
+			ctx = client.WithQueryHeight(ctx, height)
+		} else {
+			var header metadata.MD
+			if err := q.invoke(ctx, method, req, reply, append(opts, grpc.Header(&header))...); err != nil {
+				return err
+			}
+			if height, _ := client.GetHeightFromMetadata(header); height > 0 {
+				q.Options.Height = height
+			}
+			return nil
+		}
+	}
+	return q.invoke(ctx, method, req, reply, opts...)
+}
+
+// invoke sends the query over gRPC or RPC according to the route of the query options.
+func (q *Query) invoke(ctx context.Context, method string, req, reply interface{}, opts ...grpc.CallOption) error {
+	switch q.route() {
+	case RouteGRPC:
+		conn, err := q.Client.GRPCConn()
+		if err != nil {
+			return err
+		}
+		return q.invokeGRPC(ctx, conn, method, req, reply, opts...)
+	case RoutePreferGRPC:
+		conn, err := q.Client.GRPCConn()
+		if err != nil {
+			return q.Client.Invoke(ctx, method, req, reply, opts...)
+		}
+		err = q.invokeGRPC(ctx, conn, method, req, reply, opts...)
+		if fallbackToRPC(err) {
+			return q.Client.Invoke(ctx, method, req, reply, opts...)
+		}
+		return err
+	default:
+		return q.Client.Invoke(ctx, method, req, reply, opts...)
+	}
+}
+
+// invokeGRPC sends the query over the gRPC connection, and checks the height of the reply
+// like the ChainClient does for queries over RPC if the query is pinned to a height.
+func (q *Query) invokeGRPC(ctx context.Context, conn grpc.ClientConnInterface, method string, req, reply interface{}, opts ...grpc.CallOption) error {
+	height := q.height()
+	if height <= 0 {
+		return conn.Invoke(ctx, method, req, reply, opts...)
+	}
+	var header metadata.MD
+	err := conn.Invoke(ctx, method, req, reply, append(opts, grpc.Header(&header))...)
+	if fallbackToRPC(err) {
+		return err
+	}
+	return client.CheckQueryHeight(height, header, err)
+}
+
+// NewStream implements the grpc ClientConn.NewStream method
+func (q *Query) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, errors.New("streaming rpc not supported")
+}
+
+func (q *Query) height() int64 {
+	if q.Options == nil {
+		return 0
+	}
+	return q.Options.Height
+}
+
+func (q *Query) route() Route {
+	if q.Options == nil {
+		return RouteRPC
+	}
+	return q.Options.Route
+}
+
+// fallbackToRPC returns whether a query that failed over gRPC with err may succeed over RPC.
+func fallbackToRPC(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Unimplemented:
+		return true
+	default:
+		return false
+	}
+}
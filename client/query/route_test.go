@@ -0,0 +1,53 @@
+package query
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type bankParamsServer struct {
+	bankTypes.UnimplementedQueryServer
+}
+
+func (*bankParamsServer) Params(context.Context, *bankTypes.QueryParamsRequest) (*bankTypes.QueryParamsResponse, error) {
+	return &bankTypes.QueryParamsResponse{Params: bankTypes.Params{DefaultSendEnabled: true}}, nil
+}
+
+func TestRouteGRPC(t *testing.T) {
+	homepath := t.TempDir()
+	cl, err := client.NewChainClient(zaptest.NewLogger(t), client.GetCosmosHubConfig(homepath, true), homepath, nil, nil)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(cl.Codec.InterfaceRegistry).GRPCCodec()))
+	bankTypes.RegisterQueryServer(srv, &bankParamsServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	cl.Config.GRPCAddr = lis.Addr().String()
+
+	q := Query{Client: cl, Options: &QueryOptions{Route: RouteGRPC}}
+	params, err := q.Bank_Params()
+	require.NoError(t, err)
+	require.True(t, params.Params.DefaultSendEnabled)
+
+	_, err = q.Bank_SupplyOf("uatom")
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	require.True(t, fallbackToRPC(err))
+
+	_, err = ParseRoute("grpc-only")
+	require.Error(t, err)
+	route, err := ParseRoute("")
+	require.NoError(t, err)
+	require.Equal(t, RouteRPC, route)
+}
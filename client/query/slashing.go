@@ -0,0 +1,51 @@
+package query
+
+import (
+	"github.com/cosmos/cosmos-sdk/types/query"
+	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+)
+
+// slashing_ParamsRPC returns the slashing params
+func slashing_ParamsRPC(q *Query) (*slashingTypes.QueryParamsResponse, error) {
+	req := &slashingTypes.QueryParamsRequest{}
+	queryClient := slashingTypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.Params(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// slashing_SigningInfoRPC returns the signing info of the validator with the given consensus address
+func slashing_SigningInfoRPC(q *Query, consAddress string) (*slashingTypes.QuerySigningInfoResponse, error) {
+	req := &slashingTypes.QuerySigningInfoRequest{ConsAddress: consAddress}
+	queryClient := slashingTypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.SigningInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// slashing_SigningInfosRPC returns the signing infos of all validators
+func slashing_SigningInfosRPC(q *Query) (*slashingTypes.QuerySigningInfosResponse, error) {
+	info, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]slashingTypes.ValidatorSigningInfo, *query.PageResponse, error) {
+		queryClient := slashingTypes.NewQueryClient(q)
+		req := &slashingTypes.QuerySigningInfosRequest{Pagination: pr}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.SigningInfos(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Info, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &slashingTypes.QuerySigningInfosResponse{Info: info, Pagination: page}, nil
+}
@@ -0,0 +1,50 @@
+package query
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// snapshotConcurrency is how many queries of a snapshot run at the same time.
+const snapshotConcurrency = 4
+
+// Snapshot returns a copy of the query pinned to the latest height of the chain, so the
+// queries run with it all see the state of the same block. The height of the options
+// is used instead if it is set. Queries at a height the node has pruned fail with
+// client.ErrHeightPruned.
+func (q *Query) Snapshot(ctx context.Context) (*Query, error) {
+	opts := QueryOptions{}
+	if q.Options != nil {
+		opts = *q.Options
+	}
+	if opts.Height <= 0 {
+		snapshot, err := q.Client.Snapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		opts.Height = snapshot.Height
+	}
+	return &Query{Client: q.Client, Options: &opts, ctx: q.ctx}, nil
+}
+
+// RunSnapshot runs a batch of queries with a snapshot of q, concurrently. It returns the
+// height of the snapshot, and the first error of the queries.
+func (q *Query) RunSnapshot(ctx context.Context, queries ...func(*Query) error) (int64, error) {
+	snapshot, err := q.Snapshot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var eg errgroup.Group
+	eg.SetLimit(snapshotConcurrency)
+	for _, query := range queries {
+		query := query
+		eg.Go(func() error {
+			return query(snapshot)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return 0, err
+	}
+	return snapshot.Options.Height, nil
+}
@@ -0,0 +1,93 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// heightServer answers bank params queries at the height of the request like a node that
+// pruned the state below prunedBelow, except for a height it answers at the next one.
+type heightServer struct {
+	bankTypes.UnimplementedQueryServer
+	prunedBelow int64
+	wrongHeight int64
+	queries     atomic.Int64
+}
+
+func (s *heightServer) Params(ctx context.Context, _ *bankTypes.QueryParamsRequest) (*bankTypes.QueryParamsResponse, error) {
+	s.queries.Add(1)
+	md, _ := metadata.FromIncomingContext(ctx)
+	height, err := strconv.ParseInt(md.Get(grpctypes.GRPCBlockHeightHeader)[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if height < s.prunedBelow {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf(
+			"failed to load state at height %d; version does not exist (latest height: 100): invalid request", height))
+	}
+	if height == s.wrongHeight {
+		height++
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))); err != nil {
+		return nil, err
+	}
+	return &bankTypes.QueryParamsResponse{}, nil
+}
+
+func TestSnapshot(t *testing.T) {
+	homepath := t.TempDir()
+	cl, err := client.NewChainClient(zaptest.NewLogger(t), client.GetCosmosHubConfig(homepath, true), homepath, nil, nil)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(cl.Codec.InterfaceRegistry).GRPCCodec()))
+	hs := &heightServer{prunedBelow: 10, wrongHeight: 42}
+	bankTypes.RegisterQueryServer(srv, hs)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	cl.Config.GRPCAddr = lis.Addr().String()
+
+	// The height of the options is used rather than the latest height
+	q := Query{Client: cl, Options: &QueryOptions{Route: RouteGRPC, Height: 50}}
+	ctx := context.Background()
+	query := func(q *Query) error {
+		_, err := q.Bank_Params()
+		return err
+	}
+	height, err := q.RunSnapshot(ctx, query, query, query)
+	require.NoError(t, err)
+	require.Equal(t, int64(50), height)
+	require.Equal(t, int64(3), hs.queries.Load())
+
+	q.Options.Height = 5
+	_, err = q.RunSnapshot(ctx, query)
+	require.ErrorIs(t, err, client.ErrHeightPruned)
+
+	q.Options.Height = 42
+	_, err = q.RunSnapshot(ctx, query)
+	require.ErrorIs(t, err, client.ErrHeightMismatch)
+
+	// The queries of the snapshot are canceled with the context of q
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	q.Options.Height = 50
+	q.ctx = canceled
+	_, err = q.RunSnapshot(ctx, query)
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
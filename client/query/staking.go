@@ -1,13 +1,14 @@
 package query
 
 import (
+	"github.com/cosmos/cosmos-sdk/types/query"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 // staking_ParamsRPC returns the staking params
 func staking_ParamsRPC(q *Query) (*stakingTypes.QueryParamsResponse, error) {
 	req := &stakingTypes.QueryParamsRequest{}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
+	queryClient := stakingTypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.Params(ctx, req)
@@ -20,7 +21,7 @@ func staking_ParamsRPC(q *Query) (*stakingTypes.QueryParamsResponse, error) {
 // staking_PoolRPC returns the distribution params
 func staking_PoolRPC(q *Query) (*stakingTypes.QueryPoolResponse, error) {
 	req := &stakingTypes.QueryPoolRequest{}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
+	queryClient := stakingTypes.NewQueryClient(q)
 	ctx, cancel := q.GetQueryContext()
 	defer cancel()
 	res, err := queryClient.Pool(ctx, req)
@@ -42,7 +43,7 @@ func staking_DelegationRPC(q *Query, delegator, validator string) (*stakingTypes
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
+	queryClient := stakingTypes.NewQueryClient(q)
 	req := &stakingTypes.QueryDelegationRequest{
 		DelegatorAddr: delegator,
 		ValidatorAddr: validator,
@@ -68,7 +69,7 @@ func staking_UnbondingDelegationRPC(q *Query, delegator, validator string) (*sta
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
+	queryClient := stakingTypes.NewQueryClient(q)
 	req := &stakingTypes.QueryUnbondingDelegationRequest{
 		DelegatorAddr: delegator,
 		ValidatorAddr: validator,
@@ -89,18 +90,24 @@ func staking_DelegatorDelegationsRPC(q *Query, delegator string) (*stakingTypes.
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
-	req := &stakingTypes.QueryDelegatorDelegationsRequest{
-		DelegatorAddr: delegator,
-		Pagination:    q.Options.Pagination,
-	}
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.DelegatorDelegations(ctx, req)
+	delegationResponses, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) (stakingTypes.DelegationResponses, *query.PageResponse, error) {
+		queryClient := stakingTypes.NewQueryClient(q)
+		req := &stakingTypes.QueryDelegatorDelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    pr,
+		}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.DelegatorDelegations(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.DelegationResponses, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &stakingTypes.QueryDelegatorDelegationsResponse{DelegationResponses: delegationResponses, Pagination: page}, nil
 }
 
 // staking_DelegatorUnbondingDelegationsRPC returns all the delegations
@@ -110,34 +117,46 @@ func staking_DelegatorUnbondingDelegationsRPC(q *Query, delegator string) (*stak
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
-	req := &stakingTypes.QueryDelegatorUnbondingDelegationsRequest{
-		DelegatorAddr: delegator,
-		Pagination:    q.Options.Pagination,
-	}
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.DelegatorUnbondingDelegations(ctx, req)
+	unbondingResponses, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]stakingTypes.UnbondingDelegation, *query.PageResponse, error) {
+		queryClient := stakingTypes.NewQueryClient(q)
+		req := &stakingTypes.QueryDelegatorUnbondingDelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    pr,
+		}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.DelegatorUnbondingDelegations(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.UnbondingResponses, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &stakingTypes.QueryDelegatorUnbondingDelegationsResponse{UnbondingResponses: unbondingResponses, Pagination: page}, nil
 }
 
 // staking_ValidatorsRPC returns all the validators for a given status
 func staking_ValidatorsRPC(q *Query, status string) (*stakingTypes.QueryValidatorsResponse, error) {
-	queryClient := stakingTypes.NewQueryClient(q.Client)
-	req := &stakingTypes.QueryValidatorsRequest{
-		Status:     status,
-		Pagination: q.Options.Pagination,
-	}
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.Validators(ctx, req)
+	validators, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]stakingTypes.Validator, *query.PageResponse, error) {
+		queryClient := stakingTypes.NewQueryClient(q)
+		req := &stakingTypes.QueryValidatorsRequest{
+			Status:     status,
+			Pagination: pr,
+		}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.Validators(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Validators, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &stakingTypes.QueryValidatorsResponse{Validators: validators, Pagination: page}, nil
 }
 
 // staking_ValidatorRPC returns all the validator for a given address
@@ -147,7 +166,7 @@ func staking_ValidatorRPC(q *Query, address string) (*stakingTypes.QueryValidato
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
+	queryClient := stakingTypes.NewQueryClient(q)
 	req := &stakingTypes.QueryValidatorRequest{
 		ValidatorAddr: address,
 	}
@@ -167,18 +186,24 @@ func staking_ValidatorDelegationsRPC(q *Query, validator string) (*stakingTypes.
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
-	req := &stakingTypes.QueryValidatorDelegationsRequest{
-		ValidatorAddr: validator,
-		Pagination:    q.Options.Pagination,
-	}
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.ValidatorDelegations(ctx, req)
+	delegationResponses, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) (stakingTypes.DelegationResponses, *query.PageResponse, error) {
+		queryClient := stakingTypes.NewQueryClient(q)
+		req := &stakingTypes.QueryValidatorDelegationsRequest{
+			ValidatorAddr: validator,
+			Pagination:    pr,
+		}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.ValidatorDelegations(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.DelegationResponses, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &stakingTypes.QueryValidatorDelegationsResponse{DelegationResponses: delegationResponses, Pagination: page}, nil
 }
 
 // staking_ValidatorUnbondingDelegationsRPC returns all the unbonding delegations for a validator
@@ -188,18 +213,24 @@ func staking_ValidatorUnbondingDelegationsRPC(q *Query, validator string) (*stak
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
-	req := &stakingTypes.QueryValidatorUnbondingDelegationsRequest{
-		ValidatorAddr: validator,
-		Pagination:    q.Options.Pagination,
-	}
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.ValidatorUnbondingDelegations(ctx, req)
+	unbondingResponses, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]stakingTypes.UnbondingDelegation, *query.PageResponse, error) {
+		queryClient := stakingTypes.NewQueryClient(q)
+		req := &stakingTypes.QueryValidatorUnbondingDelegationsRequest{
+			ValidatorAddr: validator,
+			Pagination:    pr,
+		}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.ValidatorUnbondingDelegations(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.UnbondingResponses, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &stakingTypes.QueryValidatorUnbondingDelegationsResponse{UnbondingResponses: unbondingResponses, Pagination: page}, nil
 }
 
 // staking_RelegationsRPC returns all the unbonding delegations for a validator
@@ -217,20 +248,26 @@ func staking_RedelegationsRPC(q *Query, delegator string, src_validator string,
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
-	req := &stakingTypes.QueryRedelegationsRequest{
-		DelegatorAddr:    delegator,
-		SrcValidatorAddr: src_validator,
-		DstValidatorAddr: dst_validator,
-		Pagination:       q.Options.Pagination,
-	}
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.Redelegations(ctx, req)
+	redelegationResponses, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) (stakingTypes.RedelegationResponses, *query.PageResponse, error) {
+		queryClient := stakingTypes.NewQueryClient(q)
+		req := &stakingTypes.QueryRedelegationsRequest{
+			DelegatorAddr:    delegator,
+			SrcValidatorAddr: src_validator,
+			DstValidatorAddr: dst_validator,
+			Pagination:       pr,
+		}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.Redelegations(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.RedelegationResponses, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &stakingTypes.QueryRedelegationsResponse{RedelegationResponses: redelegationResponses, Pagination: page}, nil
 }
 
 // staking_DelegatorValidatorsRPC returns all the validators for a given delegator
@@ -240,18 +277,24 @@ func staking_DelegatorValidatorsRPC(q *Query, delegator string) (*stakingTypes.Q
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
-	req := &stakingTypes.QueryDelegatorValidatorsRequest{
-		DelegatorAddr: delegator,
-		Pagination:    q.Options.Pagination,
-	}
-	ctx, cancel := q.GetQueryContext()
-	defer cancel()
-	res, err := queryClient.DelegatorValidators(ctx, req)
+	validators, page, err := Paginate(q, func(q *Query, pr *query.PageRequest) ([]stakingTypes.Validator, *query.PageResponse, error) {
+		queryClient := stakingTypes.NewQueryClient(q)
+		req := &stakingTypes.QueryDelegatorValidatorsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    pr,
+		}
+		ctx, cancel := q.GetQueryContext()
+		defer cancel()
+		res, err := queryClient.DelegatorValidators(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Validators, res.Pagination, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return &stakingTypes.QueryDelegatorValidatorsResponse{Validators: validators, Pagination: page}, nil
 }
 
 // staking_DelegatorValidatorRPC returns the validators for a given delegator
@@ -265,7 +308,7 @@ func staking_DelegatorValidatorRPC(q *Query, delegator string, validator string)
 	if err != nil {
 		return nil, err
 	}
-	queryClient := stakingTypes.NewQueryClient(q.Client)
+	queryClient := stakingTypes.NewQueryClient(q)
 	req := &stakingTypes.QueryDelegatorValidatorRequest{
 		DelegatorAddr: delegator,
 		ValidatorAddr: validator,
@@ -281,7 +324,7 @@ func staking_DelegatorValidatorRPC(q *Query, delegator string, validator string)
 
 // staking_HistoricalInfoRPC returns the validators for a given delegator
 func staking_HistoricalInfoRPC(q *Query, height int64) (*stakingTypes.QueryHistoricalInfoResponse, error) {
-	queryClient := stakingTypes.NewQueryClient(q.Client)
+	queryClient := stakingTypes.NewQueryClient(q)
 	req := &stakingTypes.QueryHistoricalInfoRequest{
 		Height: height,
 	}
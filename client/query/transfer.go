@@ -0,0 +1,34 @@
+package query
+
+import (
+	"strings"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+)
+
+// transfer_DenomTraceRPC returns the denom trace of an ibc/ denom or its hash
+func transfer_DenomTraceRPC(q *Query, hash string) (*transfertypes.QueryDenomTraceResponse, error) {
+	// Chains before ibc-go v7 only accept the hash
+	req := &transfertypes.QueryDenomTraceRequest{Hash: strings.TrimPrefix(hash, "ibc/")}
+	queryClient := transfertypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.DenomTrace(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// transfer_EscrowAddressRPC returns the address escrowing the tokens sent over a channel
+func transfer_EscrowAddressRPC(q *Query, portId string, channelId string) (*transfertypes.QueryEscrowAddressResponse, error) {
+	req := &transfertypes.QueryEscrowAddressRequest{PortId: portId, ChannelId: channelId}
+	queryClient := transfertypes.NewQueryClient(q)
+	ctx, cancel := q.GetQueryContext()
+	defer cancel()
+	res, err := queryClient.EscrowAddress(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
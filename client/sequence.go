@@ -0,0 +1,126 @@
+package client
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/avast/retry-go/v4"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// expectedSequenceRegexp matches the log the ante handler returns when a tx
+// is signed with the wrong sequence, e.g.
+// "account sequence mismatch, expected 10, got 9: incorrect account sequence"
+var expectedSequenceRegexp = regexp.MustCompile(`account sequence mismatch, expected (\d+), got \d+`)
+
+// sequenceManager hands out account sequences per signer so that many txs
+// from the same key can sit in the mempool at once instead of one per block.
+type sequenceManager struct {
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+// accountSequence is the locally tracked account number and next sequence of
+// a single signer. mu is held from the moment a sequence is handed out until
+// the signed tx has passed CheckTx, so txs from the same signer reach the
+// mempool in sequence order.
+type accountSequence struct {
+	mu sync.Mutex
+
+	synced   bool
+	number   uint64
+	sequence uint64
+}
+
+// account returns the sequence tracker for addr, creating it if needed.
+func (sm *sequenceManager) account(addr sdk.AccAddress) *accountSequence {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.accounts == nil {
+		sm.accounts = make(map[string]*accountSequence)
+	}
+	acc, ok := sm.accounts[string(addr)]
+	if !ok {
+		acc = &accountSequence{}
+		sm.accounts[string(addr)] = acc
+	}
+	return acc
+}
+
+// syncSequence queries the account number and sequence of addr from chain
+// unless acc already holds them. acc.mu must be held by the caller.
+func (cc *ChainClient) syncSequence(acc *accountSequence, addr sdk.AccAddress) error {
+	if acc.synced {
+		return nil
+	}
+
+	var num, seq uint64
+	if err := retry.Do(func() error {
+		var err error
+		num, seq, err = cc.GetAccountNumberSequence(client.Context{}, addr)
+		return err
+	}, RtyAtt, RtyDel, RtyErr); err != nil {
+		return err
+	}
+
+	acc.number, acc.sequence, acc.synced = num, seq, true
+	return nil
+}
+
+// handleError updates the tracked sequence after a tx signed with it failed to
+// make it into the mempool. acc.mu must be held by the caller.
+func (acc *accountSequence) handleError(err error, syncRes *ctypes.ResultBroadcastTx) {
+	var log string
+	if syncRes != nil {
+		log = syncRes.Log
+	}
+
+	switch {
+	case isWrongSequence(err):
+		// The node tells us which sequence it expects, so we can skip the query.
+		// It may reflect pending mempool txs the account query would not see.
+		if seq, ok := parseExpectedSequence(log); ok {
+			acc.sequence = seq
+			return
+		}
+		if seq, ok := parseExpectedSequence(err.Error()); ok {
+			acc.sequence = seq
+			return
+		}
+		acc.synced = false
+	case syncRes != nil && syncRes.Code != 0:
+		// CheckTx rejected the tx, so the sequence was not consumed.
+	default:
+		// We don't know whether the tx reached the mempool, resync from chain.
+		acc.synced = false
+	}
+}
+
+// isWrongSequence reports whether err was caused by signing with a sequence
+// other than the one expected by the node. Simulation errors arrive as plain
+// gRPC status errors, so the message is matched as well.
+func isWrongSequence(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, sdkerrors.ErrWrongSequence) || expectedSequenceRegexp.MatchString(err.Error())
+}
+
+// parseExpectedSequence extracts the expected sequence from a wrong sequence log.
+func parseExpectedSequence(log string) (uint64, bool) {
+	matches := expectedSequenceRegexp.FindStringSubmatch(log)
+	if len(matches) != 2 {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return seq, true
+}
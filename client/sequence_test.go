@@ -0,0 +1,111 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpectedSequence(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		log    string
+		seq    uint64
+		parsed bool
+	}{
+		{
+			name:   "check tx log",
+			log:    "account sequence mismatch, expected 10, got 9: incorrect account sequence",
+			seq:    10,
+			parsed: true,
+		},
+		{
+			name:   "simulation error",
+			log:    "rpc error: code = Unknown desc = account sequence mismatch, expected 4321, got 4322: incorrect account sequence [cosmos/cosmos-sdk@v0.47.3/x/auth/ante/sigverify.go:269] With gas wanted: '0' and gas used: '47538' : unknown request",
+			seq:    4321,
+			parsed: true,
+		},
+		{
+			name: "unrelated log",
+			log:  "insufficient fees; got: 1uatom required: 2uatom: insufficient fee",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			seq, ok := parseExpectedSequence(tt.log)
+			assert.Equal(t, tt.parsed, ok)
+			assert.Equal(t, tt.seq, seq)
+		})
+	}
+}
+
+func TestAccountSequenceHandleError(t *testing.T) {
+	wrongSeq := fmt.Errorf("error broadcasting transaction: %w", sdkerrors.ErrWrongSequence)
+
+	for _, tt := range []struct {
+		name      string
+		err       error
+		syncRes   *ctypes.ResultBroadcastTx
+		expSeq    uint64
+		expSynced bool
+	}{
+		{
+			name: "wrong sequence resyncs from check tx log",
+			err:  wrongSeq,
+			syncRes: &ctypes.ResultBroadcastTx{
+				Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+				Codespace: sdkerrors.ErrWrongSequence.Codespace(),
+				Log:       "account sequence mismatch, expected 12, got 5: incorrect account sequence",
+			},
+			expSeq:    12,
+			expSynced: true,
+		},
+		{
+			name:      "wrong sequence resyncs from simulation error",
+			err:       errors.New("account sequence mismatch, expected 7, got 5: incorrect account sequence"),
+			expSeq:    7,
+			expSynced: true,
+		},
+		{
+			name:    "wrong sequence without expected sequence queries chain",
+			err:     wrongSeq,
+			syncRes: &ctypes.ResultBroadcastTx{Code: sdkerrors.ErrWrongSequence.ABCICode()},
+			expSeq:  5,
+		},
+		{
+			name: "rejected tx keeps sequence",
+			err:  sdkerrors.ErrInsufficientFee,
+			syncRes: &ctypes.ResultBroadcastTx{
+				Code:      sdkerrors.ErrInsufficientFee.ABCICode(),
+				Codespace: sdkerrors.ErrInsufficientFee.Codespace(),
+			},
+			expSeq:    5,
+			expSynced: true,
+		},
+		{
+			name:   "transport error queries chain",
+			err:    errExpected,
+			expSeq: 5,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := &accountSequence{synced: true, number: 1, sequence: 5}
+			acc.handleError(tt.err, tt.syncRes)
+			assert.Equal(t, tt.expSeq, acc.sequence)
+			assert.Equal(t, tt.expSynced, acc.synced)
+		})
+	}
+}
+
+func TestSequenceManagerAccount(t *testing.T) {
+	var sm sequenceManager
+	alice, bob := sdk.AccAddress("alice"), sdk.AccAddress("bob")
+
+	acc := sm.account(alice)
+	assert.Same(t, acc, sm.account(alice))
+	assert.NotSame(t, acc, sm.account(bob))
+}
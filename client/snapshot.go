@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrHeightPruned is returned by queries pinned to a height the node has pruned the state of.
+	ErrHeightPruned = errors.New("the node has pruned the state at the query height, query an archive node")
+	// ErrHeightMismatch is returned by queries pinned to a height that are answered at another one.
+	ErrHeightMismatch = errors.New("the query was answered at another height than it was pinned to")
+)
+
+// Snapshot is a height of the chain that queries are pinned to, so that the replies of
+// several queries come from the same state. A query the node can't serve at the height
+// fails with ErrHeightPruned instead of being answered at another height.
+type Snapshot struct {
+	Height int64
+
+	cc *ChainClient
+}
+
+var _ gogogrpc.ClientConn = &Snapshot{}
+
+// Snapshot returns a snapshot at the latest height of the chain.
+func (cc *ChainClient) Snapshot(ctx context.Context) (*Snapshot, error) {
+	height, err := cc.queryLatestHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cc.SnapshotAt(height), nil
+}
+
+// SnapshotAt returns a snapshot at height.
+func (cc *ChainClient) SnapshotAt(height int64) *Snapshot {
+	return &Snapshot{Height: height, cc: cc}
+}
+
+// Context returns ctx with the height of the snapshot, replacing any height it had.
+func (s *Snapshot) Context(ctx context.Context) context.Context {
+	return WithQueryHeight(ctx, s.Height)
+}
+
+// Invoke implements the grpc ClientConn.Invoke method, it runs the query at the height of the snapshot.
+func (s *Snapshot) Invoke(ctx context.Context, method string, req, reply interface{}, opts ...grpc.CallOption) error {
+	return s.cc.Invoke(s.Context(ctx), method, req, reply, opts...)
+}
+
+// NewStream implements the grpc ClientConn.NewStream method
+func (s *Snapshot) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, fmt.Errorf("streaming rpc not supported")
+}
+
+// WithQueryHeight returns ctx with the height header of queries set to height. Unlike
+// SetHeightOnContext, it replaces the height ctx had instead of adding a second one.
+func WithQueryHeight(ctx context.Context, height int64) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// CheckQueryHeight checks the reply to a query pinned to height, with the header metadata
+// and error of the reply. If the node has pruned the height the error is wrapped in
+// ErrHeightPruned, and the x-cosmos-block-height header of a successful reply must be height.
+func CheckQueryHeight(height int64, header metadata.MD, err error) error {
+	if err != nil {
+		if isPrunedHeightError(err) {
+			return fmt.Errorf("%w: height %d: %s", ErrHeightPruned, height, status.Convert(err).Message())
+		}
+		return err
+	}
+	replyHeight, err := GetHeightFromMetadata(header)
+	if err != nil {
+		return fmt.Errorf("invalid %s header of the reply: %w", grpctypes.GRPCBlockHeightHeader, err)
+	}
+	if replyHeight != height {
+		return fmt.Errorf("%w: pinned to %d, answered at %d", ErrHeightMismatch, height, replyHeight)
+	}
+	return nil
+}
+
+// isPrunedHeightError returns whether err is the error of the SDK to a query at a height
+// that the node hasn't got the state of.
+func isPrunedHeightError(err error) bool {
+	msg := status.Convert(err).Message()
+	return strings.Contains(msg, "failed to load state at height") ||
+		strings.Contains(msg, "version does not exist")
+}
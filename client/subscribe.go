@@ -0,0 +1,492 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+// EventKind is the kind of events of a subscription.
+type EventKind string
+
+const (
+	// EventNewBlock is the event of each new block.
+	EventNewBlock EventKind = "NewBlock"
+	// EventTx is the event of each tx matching the query of the subscription.
+	EventTx EventKind = "Tx"
+	// EventValidatorSetUpdates is the event of each block updating the validator set.
+	EventValidatorSetUpdates EventKind = "ValidatorSetUpdates"
+)
+
+const (
+	// subscribeStallTimeout is how long a subscription waits for a new block before it
+	// considers its connection dead and reconnects.
+	subscribeStallTimeout = time.Minute
+	// subscribeMaxBackoff is the longest a subscription waits before reconnecting.
+	subscribeMaxBackoff = 30 * time.Second
+	// subscribeAckTimeout is how long subscribing waits for the node to accept the query.
+	subscribeAckTimeout = 10 * time.Second
+	// backfillPerPage is the page size of the tx searches backfilling a subscription.
+	backfillPerPage = 100
+)
+
+// Event is an event of a subscription, the field set depends on its kind.
+type Event struct {
+	Kind   EventKind
+	Height int64
+
+	// Block is the block of EventNewBlock.
+	Block *tmtypes.Block
+	// Tx is the tx of EventTx, decoded like the result of QueryTx.
+	Tx *sdk.TxResponse
+	// ValidatorUpdates are the updates of EventValidatorSetUpdates.
+	ValidatorUpdates []*tmtypes.Validator
+}
+
+// SubscribeOption changes how Subscribe subscribes.
+type SubscribeOption func(*subscription)
+
+// SubscribeFromHeight makes the subscription start with the events of the blocks from
+// height up to the latest block, like a subscription that resumes from height.
+func SubscribeFromHeight(height int64) SubscribeOption {
+	return func(s *subscription) {
+		s.fromHeight = height
+	}
+}
+
+var (
+	// queryAnd matches the AND separating two conditions of an event query.
+	queryAnd = regexp.MustCompile(`^\s+AND\s+`)
+	// eventQueryKind matches the tm.event condition of an event query.
+	eventQueryKind = regexp.MustCompile(`^tm\.event\s*=\s*'(\w+)'$`)
+)
+
+// ParseEventQuery returns the kind of events of a CometBFT event query and the conditions
+// on the events of txs of the query. A query without a tm.event condition is a tx query.
+func ParseEventQuery(query string) (EventKind, string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return EventTx, "", nil
+	}
+	if _, err := cmtquery.New(query); err != nil {
+		return "", "", fmt.Errorf("invalid event query: %w", err)
+	}
+
+	kind := EventTx
+	var conditions []string
+	for _, cond := range splitQueryConditions(query) {
+		if m := eventQueryKind.FindStringSubmatch(cond); m != nil {
+			kind = EventKind(m[1])
+		} else if cond != "" {
+			conditions = append(conditions, cond)
+		}
+	}
+	query = strings.Join(conditions, " AND ")
+
+	switch kind {
+	case EventTx:
+		return kind, query, nil
+	case EventNewBlock, EventValidatorSetUpdates:
+		if query != "" {
+			return "", "", fmt.Errorf("%s events can't be filtered, remove %q from the query", kind, query)
+		}
+		return kind, "", nil
+	default:
+		return "", "", fmt.Errorf("unsupported event kind %q, expected %s, %s or %s", kind, EventNewBlock, EventTx, EventValidatorSetUpdates)
+	}
+}
+
+// splitQueryConditions splits a valid event query on the AND separating its conditions,
+// leaving the AND inside quoted values like tx.memo='a AND b'.
+func splitQueryConditions(query string) []string {
+	var conditions []string
+	quoted, start := false, 0
+	for i := 0; i < len(query); i++ {
+		switch {
+		case query[i] == '\'':
+			quoted = !quoted
+		case !quoted:
+			if sep := queryAnd.FindString(query[i:]); sep != "" {
+				conditions = append(conditions, query[start:i])
+				start = i + len(sep)
+				i = start - 1
+			}
+		}
+	}
+	return append(conditions, query[start:])
+}
+
+// Subscribe returns a channel of the events of kind, for EventTx of the txs matching
+// query, the conditions of a tx search like "message.sender='cosmos1...'". The query
+// must be empty for other kinds.
+//
+// The subscription connects to the best RPC endpoint of the chain, and reconnects to its
+// RPC addresses in turn, best first, when the connection is lost, or when no new block arrives for a minute, and resumes from the
+// last height it has sent the events of, so no events are missed or sent twice. The
+// channel is closed when ctx is done.
+func (cc *ChainClient) Subscribe(ctx context.Context, kind EventKind, query string, opts ...SubscribeOption) (<-chan Event, error) {
+	if kind != EventTx && query != "" {
+		return nil, fmt.Errorf("%s events can't be filtered by a query", kind)
+	}
+	addrs := cc.bestRPCAddrs()
+	if len(addrs) == 0 {
+		return nil, errors.New("no RPC address configured")
+	}
+	s := &subscription{
+		cc:    cc,
+		kind:  kind,
+		query: query,
+		addrs: addrs,
+		out:   make(chan Event),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// Connect once before returning, so an invalid query fails right away
+	ws, err := s.connect(ctx, s.addrs[0])
+	if err != nil {
+		return nil, err
+	}
+	go s.run(ctx, ws)
+	return s.out, nil
+}
+
+// bestRPCAddrs returns the RPC addresses of the chain, best first if its RPC client is an
+// EndpointPool.
+func (cc *ChainClient) bestRPCAddrs() []string {
+	if pool, ok := cc.RPCClient.(*EndpointPool); ok {
+		return pool.Addresses()
+	}
+	return cc.Config.rpcAddrs()
+}
+
+// subscription follows the events of a kind over a websocket, and backfills the events it
+// missed over RPC after it reconnects.
+type subscription struct {
+	cc         *ChainClient
+	kind       EventKind
+	query      string
+	addrs      []string
+	fromHeight int64
+	out        chan Event
+
+	// lastHeight is the last height the events of were all sent, for txs the height of the
+	// last block header, the txs of a block come after its header.
+	lastHeight int64
+	// lastTx is the height and index of the last tx sent.
+	lastTx [2]int64
+}
+
+// queries returns the queries to subscribe to, the first one is a query of new blocks or
+// headers that tracks the progress of the chain.
+func (s *subscription) queries() []string {
+	if s.kind != EventTx {
+		return []string{tmtypes.QueryForEvent(tmtypes.EventNewBlock).String()}
+	}
+	txQuery := tmtypes.QueryForEvent(tmtypes.EventTx).String()
+	if s.query != "" {
+		txQuery += " AND " + s.query
+	}
+	return []string{tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String(), txQuery}
+}
+
+// connect dials a websocket to addr and subscribes to the queries, it waits for the node
+// to accept them.
+func (s *subscription) connect(ctx context.Context, addr string) (*jsonrpcclient.WSClient, error) {
+	ws, err := jsonrpcclient.NewWS(addr, "/websocket", jsonrpcclient.MaxReconnectAttempts(0))
+	if err != nil {
+		return nil, err
+	}
+	// The subscription reconnects itself so it can backfill the events it missed, the
+	// client stops when it loses the connection instead of redialing without them
+	dial, dialed := ws.Dialer, false
+	ws.Dialer = func(network, addr string) (net.Conn, error) {
+		if dialed {
+			return nil, errors.New("the subscription reconnects itself")
+		}
+		dialed = true
+		return dial(network, addr)
+	}
+	if err := ws.Start(); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, subscribeAckTimeout)
+	defer cancel()
+	for _, query := range s.queries() {
+		if err := ws.Subscribe(ctx, query); err != nil {
+			_ = ws.Stop()
+			return nil, err
+		}
+		select {
+		case res, ok := <-ws.ResponsesCh:
+			switch {
+			case !ok:
+				err = fmt.Errorf("connection to %s closed", addr)
+			case res.Error != nil:
+				err = fmt.Errorf("failed to subscribe to %q: %w", query, res.Error)
+			}
+		case <-ctx.Done():
+			err = fmt.Errorf("failed to subscribe to %q: %w", query, ctx.Err())
+		}
+		if err != nil {
+			_ = ws.Stop()
+			return nil, err
+		}
+	}
+	return ws, nil
+}
+
+// run serves the subscription over ws, then reconnects until ctx is done.
+func (s *subscription) run(ctx context.Context, ws *jsonrpcclient.WSClient) {
+	defer close(s.out)
+
+	var err error
+	backoff := time.Second
+	// failures is the number of connections that failed in a row
+	failures := 0
+	for {
+		if ws != nil {
+			sent := s.lastHeight
+			err = s.serve(ctx, ws)
+			if s.lastHeight > sent {
+				backoff = time.Second
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.cc.log.Warn("Subscription interrupted, reconnecting",
+			zap.String("kind", string(s.kind)),
+			zap.Int64("last_height", s.lastHeight),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > subscribeMaxBackoff {
+			backoff = subscribeMaxBackoff
+		}
+
+		// Reconnect to the best endpoint, and to the next ones in turn while connecting fails
+		// in case the node is down
+		s.addrs = s.cc.bestRPCAddrs()
+		if ws, err = s.connect(ctx, s.addrs[failures%len(s.addrs)]); err != nil {
+			failures++
+		} else {
+			failures = 0
+		}
+	}
+}
+
+// serve backfills the events since the last height, then sends the events of ws until the
+// connection is lost, stalls or ctx is done.
+func (s *subscription) serve(ctx context.Context, ws *jsonrpcclient.WSClient) error {
+	defer func() {
+		if ws.IsRunning() {
+			_ = ws.Stop()
+		}
+	}()
+
+	if err := s.backfill(ctx); err != nil {
+		return fmt.Errorf("failed to backfill events: %w", err)
+	}
+
+	stall := time.NewTimer(subscribeStallTimeout)
+	defer stall.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ws.Quit():
+			return errors.New("connection lost")
+		case <-stall.C:
+			return fmt.Errorf("no new block for %s", subscribeStallTimeout)
+		case res, ok := <-ws.ResponsesCh:
+			if !ok {
+				return errors.New("connection closed")
+			}
+			if res.Error != nil {
+				return res.Error
+			}
+			var ev ctypes.ResultEvent
+			if err := cmtjson.Unmarshal(res.Result, &ev); err != nil {
+				return fmt.Errorf("failed to decode event: %w", err)
+			}
+			switch data := ev.Data.(type) {
+			case tmtypes.EventDataNewBlock:
+				stall.Reset(subscribeStallTimeout)
+				if err := s.sendBlock(ctx, data.Block, data.ResultEndBlock.ValidatorUpdates); err != nil {
+					return err
+				}
+			case tmtypes.EventDataNewBlockHeader:
+				stall.Reset(subscribeStallTimeout)
+				if data.Header.Height > s.lastHeight {
+					s.lastHeight = data.Header.Height
+				}
+			case tmtypes.EventDataTx:
+				if err := s.sendTx(ctx, &ctypes.ResultTx{
+					Hash:     tmtypes.Tx(data.Tx).Hash(),
+					Height:   data.Height,
+					Index:    data.Index,
+					TxResult: data.Result,
+					Tx:       data.Tx,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// backfill sends the events from the last height, or the height to subscribe from, up to
+// the latest block.
+func (s *subscription) backfill(ctx context.Context) error {
+	if s.kind == EventTx {
+		// The txs of the last block may not have all been sent, the sent ones are skipped
+		from := s.lastHeight
+		if s.lastTx[0] > from {
+			from = s.lastTx[0]
+		}
+		if from == 0 {
+			from = s.fromHeight
+		}
+		if from == 0 {
+			return nil
+		}
+		return s.backfillTxs(ctx, from)
+	}
+
+	from := s.lastHeight + 1
+	if s.lastHeight == 0 {
+		if s.fromHeight == 0 {
+			return nil
+		}
+		from = s.fromHeight
+	}
+	status, err := s.cc.RPCClient.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for h := from; h <= status.SyncInfo.LatestBlockHeight; h++ {
+		height := h
+		if s.kind == EventNewBlock {
+			res, err := s.cc.RPCClient.Block(ctx, &height)
+			if err != nil {
+				return err
+			}
+			if err := s.sendBlock(ctx, res.Block, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		res, err := s.cc.RPCClient.BlockResults(ctx, &height)
+		if err != nil {
+			return err
+		}
+		if err := s.sendValidatorUpdates(ctx, height, res.ValidatorUpdates); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillTxs sends the txs matching the query from height.
+func (s *subscription) backfillTxs(ctx context.Context, height int64) error {
+	if height < 1 {
+		height = 1
+	}
+	query := fmt.Sprintf("tx.height >= %d", height)
+	if s.query != "" {
+		query = s.query + " AND " + query
+	}
+	perPage := backfillPerPage
+	for page := 1; ; page++ {
+		page := page
+		res, err := s.cc.RPCClient.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return err
+		}
+		for _, tx := range res.Txs {
+			if err := s.sendTx(ctx, tx); err != nil {
+				return err
+			}
+		}
+		if len(res.Txs) < perPage || page*perPage >= res.TotalCount {
+			return nil
+		}
+	}
+}
+
+func (s *subscription) sendBlock(ctx context.Context, block *tmtypes.Block, updates []abci.ValidatorUpdate) error {
+	if block.Height <= s.lastHeight {
+		return nil
+	}
+	if s.kind == EventValidatorSetUpdates {
+		return s.sendValidatorUpdates(ctx, block.Height, updates)
+	}
+	if err := s.send(ctx, Event{Kind: EventNewBlock, Height: block.Height, Block: block}); err != nil {
+		return err
+	}
+	s.lastHeight = block.Height
+	return nil
+}
+
+func (s *subscription) sendValidatorUpdates(ctx context.Context, height int64, updates []abci.ValidatorUpdate) error {
+	if height <= s.lastHeight {
+		return nil
+	}
+	if len(updates) > 0 {
+		vals, err := tmtypes.PB2TM.ValidatorUpdates(updates)
+		if err != nil {
+			return err
+		}
+		if err := s.send(ctx, Event{Kind: EventValidatorSetUpdates, Height: height, ValidatorUpdates: vals}); err != nil {
+			return err
+		}
+	}
+	s.lastHeight = height
+	return nil
+}
+
+func (s *subscription) sendTx(ctx context.Context, resTx *ctypes.ResultTx) error {
+	pos := [2]int64{resTx.Height, int64(resTx.Index)}
+	if pos[0] < s.lastTx[0] || (pos[0] == s.lastTx[0] && pos[1] <= s.lastTx[1]) {
+		return nil
+	}
+	tx, err := s.cc.mkTxResult(ctx, resTx)
+	if err != nil {
+		// The tx can't be decoded with the codec of the chain, skip it rather than stall
+		s.cc.log.Warn("Failed to decode tx of subscription", zap.String("hash", resTx.Hash.String()), zap.Error(err))
+	} else if err := s.send(ctx, Event{Kind: EventTx, Height: resTx.Height, Tx: tx}); err != nil {
+		return err
+	}
+	s.lastTx = pos
+	return nil
+}
+
+func (s *subscription) send(ctx context.Context, ev Event) error {
+	select {
+	case s.out <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// blocksClient serves the blocks up to latest over RPC.
+type blocksClient struct {
+	rpcclient.Client
+	latest int64
+}
+
+func (c blocksClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	res := &coretypes.ResultStatus{}
+	res.SyncInfo.LatestBlockHeight = c.latest
+	return res, nil
+}
+
+func (c blocksClient) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	return &coretypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: *height}}}, nil
+}
+
+// blocksNode accepts websocket subscriptions, and sends the new blocks of the heights of a
+// connection before dropping it.
+func blocksNode(t *testing.T, connections [][]int64) *httptest.Server {
+	var upgrader websocket.Upgrader
+	conns := make(chan []int64, len(connections))
+	for _, heights := range connections {
+		conns <- heights
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var req rpctypes.RPCRequest
+		require.NoError(t, conn.ReadJSON(&req))
+		require.Equal(t, "subscribe", req.Method)
+		require.NoError(t, conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, struct{}{})))
+
+		var heights []int64
+		select {
+		case heights = <-conns:
+		default:
+		}
+		for _, h := range heights {
+			ev := coretypes.ResultEvent{
+				Query: tmtypes.QueryForEvent(tmtypes.EventNewBlock).String(),
+				Data:  tmtypes.EventDataNewBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: h}}},
+			}
+			bz, err := cmtjson.Marshal(ev)
+			require.NoError(t, err)
+			require.NoError(t, conn.WriteJSON(rpctypes.RPCResponse{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(bz)}))
+		}
+		if len(heights) == 0 {
+			// Keep the last connection open
+			<-r.Context().Done()
+			return
+		}
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "restarting"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSubscribeResumes(t *testing.T) {
+	// The second connection sends block 5 after the subscription backfilled 3 and 4
+	node := blocksNode(t, [][]int64{{1, 2}, {5}})
+	// The subscription reconnects to the best node while it is up
+	other := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("reconnected to the second address")
+	}))
+	t.Cleanup(other.Close)
+	cc := &ChainClient{
+		log:       zaptest.NewLogger(t),
+		Config:    &ChainClientConfig{RPCAddr: node.URL, RPCAddrs: []string{other.URL}},
+		RPCClient: blocksClient{latest: 4},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	events, err := cc.Subscribe(ctx, EventNewBlock, "")
+	require.NoError(t, err)
+
+	var heights []int64
+	for ev := range events {
+		require.Equal(t, EventNewBlock, ev.Kind)
+		require.Equal(t, ev.Height, ev.Block.Height)
+		heights = append(heights, ev.Height)
+		if ev.Height == 5 {
+			cancel()
+		}
+	}
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, heights)
+}
+
+func TestParseEventQuery(t *testing.T) {
+	kind, query, err := ParseEventQuery("tm.event = 'Tx' AND message.sender = 'cosmos1abc' AND transfer.amount > 5")
+	require.NoError(t, err)
+	require.Equal(t, EventTx, kind)
+	require.Equal(t, "message.sender = 'cosmos1abc' AND transfer.amount > 5", query)
+
+	kind, query, err = ParseEventQuery("message.action='/cosmos.bank.v1beta1.MsgSend'")
+	require.NoError(t, err)
+	require.Equal(t, EventTx, kind)
+	require.Equal(t, "message.action='/cosmos.bank.v1beta1.MsgSend'", query)
+
+	kind, query, err = ParseEventQuery("tm.event='Tx' AND tx.memo='a AND b' AND tx.height>5")
+	require.NoError(t, err)
+	require.Equal(t, EventTx, kind)
+	require.Equal(t, "tx.memo='a AND b' AND tx.height>5", query)
+
+	_, _, err = ParseEventQuery("tx.memo='unterminated")
+	require.ErrorContains(t, err, "invalid event query")
+
+	kind, _, err = ParseEventQuery("tm.event='NewBlock'")
+	require.NoError(t, err)
+	require.Equal(t, EventNewBlock, kind)
+
+	_, _, err = ParseEventQuery("tm.event='ValidatorSetUpdates' AND tx.height > 5")
+	require.ErrorContains(t, err, "can't be filtered")
+
+	_, _, err = ParseEventQuery("tm.event='NewRound'")
+	require.ErrorContains(t, err, "unsupported event kind")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	abci "github.com/cometbft/cometbft/abci/types"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -44,8 +45,8 @@ func (ccc *ChainClientConfig) SignMode() signing.SignMode {
 	return signMode
 }
 
-func (cc *ChainClient) SendMsg(ctx context.Context, msg sdk.Msg, memo string) (*sdk.TxResponse, error) {
-	return cc.SendMsgs(ctx, []sdk.Msg{msg}, memo)
+func (cc *ChainClient) SendMsg(ctx context.Context, msg sdk.Msg, memo string, opts ...TxOption) (*sdk.TxResponse, error) {
+	return cc.SendMsgs(ctx, []sdk.Msg{msg}, memo, opts...)
 }
 
 // SendMsgs wraps the msgs in a StdTx, signs and sends it. An error is returned if there
@@ -53,30 +54,85 @@ func (cc *ChainClient) SendMsg(ctx context.Context, msg sdk.Msg, memo string) (*
 // not return an error. If a transaction is successfully sent, the result of the execution
 // of that transaction will be logged. A boolean indicating if a transaction was successfully
 // sent and executed successfully is returned.
-func (cc *ChainClient) SendMsgs(ctx context.Context, msgs []sdk.Msg, memo string) (*sdk.TxResponse, error) {
-	txf, err := cc.PrepareFactory(cc.TxFactory())
+//
+// SendMsgs is safe to call from many goroutines. Sequences are handed out locally per key,
+// so several transactions from the same key can be included in a single block.
+//
+// Unless the broadcast mode is BroadcastModeCommit, the returned response only carries
+// the hash and CheckTx result of the transaction.
+func (cc *ChainClient) SendMsgs(ctx context.Context, msgs []sdk.Msg, memo string, opts ...TxOption) (*sdk.TxResponse, error) {
+	o := cc.txOptionsFromConfig(opts...)
+	if err := validateBroadcastMode(o.broadcastMode); err != nil {
+		return nil, err
+	}
+
+	// Query how to wait for the tx before it is broadcast, a failure after the broadcast
+	// would make callers send it again
+	var wait txWaitConfig
+	if o.broadcastMode == BroadcastModeCommit {
+		var err error
+		if wait, err = cc.txWaitConfig(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var broadcastRes *ctypes.ResultBroadcastTx
+	if err := retry.Do(func() error {
+		var err error
+		broadcastRes, err = cc.signAndBroadcast(ctx, msgs, memo, o)
+		return err
+	}, retry.Context(ctx), retry.RetryIf(isWrongSequence), RtyAtt, RtyDel, RtyErr); err != nil {
+		return nil, err
+	}
+
+	if o.broadcastMode != BroadcastModeCommit {
+		return newBroadcastTxResponse(broadcastRes), nil
+	}
+
+	res, err := waitForTx(ctx, cc.RPCClient, cc.mkTxResult, broadcastRes.Hash, wait)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Make this work with new CalculateGas method
-	// TODO: This is related to GRPC client stuff?
-	// https://github.com/cosmos/cosmos-sdk/blob/5725659684fc93790a63981c653feee33ecf3225/client/tx/tx.go#L297
-	_, adjusted, err := cc.CalculateGas(ctx, txf, msgs...)
+	// transaction was executed, log the success or failure using the tx response code
+	// NOTE: error is nil, logic should use the returned error to determine if the
+	// transaction was successfully executed.
+	if res.Code != 0 {
+		return res, fmt.Errorf("transaction failed with code: %d", res.Code)
+	}
+
+	return res, nil
+}
+
+// signAndBroadcast signs the msgs with the next sequence of the configured key and
+// broadcasts them, returning once the transaction has passed CheckTx or, in async mode,
+// once it was handed to the node. The sequence of the key stays locked until then, so
+// transactions reach the mempool in order.
+func (cc *ChainClient) signAndBroadcast(ctx context.Context, msgs []sdk.Msg, memo string, o txOptions) (*ctypes.ResultBroadcastTx, error) {
+	key := cc.Config.Key
+	from, err := cc.GetKeyAddress()
 	if err != nil {
 		return nil, err
 	}
 
-	if memo != "" {
-		txf = txf.WithMemo(memo)
+	acc := cc.sequences.account(from)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if err := cc.syncSequence(acc, from); err != nil {
+		return nil, err
 	}
 
-	// Set the gas amount on the transaction factory
-	txf = txf.WithGas(adjusted)
+	txf, err := cc.PrepareFactory(cc.TxFactory().
+		WithAccountNumber(acc.number).
+		WithSequence(acc.sequence))
+	if err != nil {
+		return nil, err
+	}
 
-	// Build the transaction builder
-	txb, err := txf.BuildUnsignedTx(msgs...)
+	txb, err := cc.buildTx(ctx, txf, msgs, memo, o)
 	if err != nil {
+		acc.handleError(err, nil)
 		return nil, err
 	}
 
@@ -91,7 +147,7 @@ func (cc *ChainClient) SendMsgs(ctx context.Context, msgs []sdk.Msg, memo string
 		done := cc.SetSDKContext()
 		// ensure that we allways call done, even in case of an error or panic
 		defer done()
-		if err = tx.Sign(txf, cc.Config.Key, txb, false); err != nil {
+		if err = tx.Sign(txf, key, txb, false); err != nil {
 			return err
 		}
 		return nil
@@ -108,19 +164,75 @@ func (cc *ChainClient) SendMsgs(ctx context.Context, msgs []sdk.Msg, memo string
 	}
 
 	// Broadcast those bytes
-	res, err := cc.BroadcastTx(ctx, txBytes)
+	var res *ctypes.ResultBroadcastTx
+	if o.broadcastMode == BroadcastModeAsync {
+		// Without CheckTx we assume the sequence was consumed, a wrong guess
+		// is corrected by the next tx failing with a wrong sequence.
+		res, err = cc.RPCClient.BroadcastTxAsync(ctx, txBytes)
+	} else {
+		res, err = broadcastTxSync(ctx, cc.RPCClient, txBytes)
+	}
 	if err != nil {
+		acc.handleError(err, res)
 		return nil, err
 	}
 
-	// transaction was executed, log the success or failure using the tx response code
-	// NOTE: error is nil, logic should use the returned error to determine if the
-	// transaction was successfully executed.
-	if res.Code != 0 {
-		return res, fmt.Errorf("transaction failed with code: %d", res.Code)
+	acc.sequence++
+	return res, nil
+}
+
+// buildTx builds the unsigned transaction for msgs, simulating its gas unless
+// it was set in the options.
+func (cc *ChainClient) buildTx(ctx context.Context, txf tx.Factory, msgs []sdk.Msg, memo string, o txOptions) (client.TxBuilder, error) {
+	// Set before simulating, as the fees are deducted from the granter or payer.
+	if o.feeGranter != nil {
+		txf = txf.WithFeeGranter(o.feeGranter)
+	}
+	if o.feePayer != nil {
+		txf = txf.WithFeePayer(o.feePayer)
 	}
 
-	return res, nil
+	gas := o.gas
+	if gas == 0 {
+		if err := cc.checkSimulatable(o); err != nil {
+			return nil, err
+		}
+		// TODO: Make this work with new CalculateGas method
+		// TODO: This is related to GRPC client stuff?
+		// https://github.com/cosmos/cosmos-sdk/blob/5725659684fc93790a63981c653feee33ecf3225/client/tx/tx.go#L297
+		_, adjusted, err := cc.CalculateGas(ctx, txf, msgs...)
+		if err != nil {
+			return nil, err
+		}
+		gas = adjusted
+	}
+
+	if memo != "" {
+		txf = txf.WithMemo(memo)
+	}
+
+	// Set the gas amount on the transaction factory
+	txf = txf.WithGas(gas)
+
+	// Build the transaction builder
+	return txf.BuildUnsignedTx(msgs...)
+}
+
+// checkSimulatable returns an error when the gas of a tx with the options o can't be
+// simulated. The simulation only carries the signature of the signing key, so a tx whose
+// fees are paid by another account fails it for the missing signature of the payer.
+func (cc *ChainClient) checkSimulatable(o txOptions) error {
+	if o.feePayer == nil {
+		return nil
+	}
+	from, err := cc.GetKeyAddress()
+	if err != nil {
+		return err
+	}
+	if !from.Equals(o.feePayer) {
+		return fmt.Errorf("can't simulate the gas of a tx with fee payer %s other than the signer, set the gas limit (--gas)", cc.MustEncodeAccAddr(o.feePayer))
+	}
+	return nil
 }
 
 func (cc *ChainClient) PrepareFactory(txf tx.Factory) (tx.Factory, error) {
@@ -146,19 +258,19 @@ func (cc *ChainClient) PrepareFactory(txf tx.Factory) (tx.Factory, error) {
 		WithChainID(cc.Config.ChainID).
 		WithCodec(cc.Codec.Marshaler)
 
-	// Set the account number and sequence on the transaction factory and retry if fail
-	if err = retry.Do(func() error {
-		if err = txf.AccountRetriever().EnsureExists(cliCtx, from); err != nil {
+	// Only query the account if the caller didn't provide its number and sequence
+	initNum, initSeq := txf.AccountNumber(), txf.Sequence()
+	if initNum == 0 || initSeq == 0 {
+		// Set the account number and sequence on the transaction factory and retry if fail
+		if err = retry.Do(func() error {
+			if err = txf.AccountRetriever().EnsureExists(cliCtx, from); err != nil {
+				return err
+			}
 			return err
+		}, RtyAtt, RtyDel, RtyErr); err != nil {
+			return txf, err
 		}
-		return err
-	}, RtyAtt, RtyDel, RtyErr); err != nil {
-		return txf, err
-	}
 
-	// TODO: why this code? this may potentially require another query when we don't want one
-	initNum, initSeq := txf.AccountNumber(), txf.Sequence()
-	if initNum == 0 || initSeq == 0 {
 		if err = retry.Do(func() error {
 			num, seq, err = txf.AccountRetriever().GetAccountNumberSequence(cliCtx, from)
 			if err != nil {
@@ -229,6 +341,15 @@ func (cc *ChainClient) CalculateGas(ctx context.Context, txf tx.Factory, msgs ..
 }
 
 func (cc *ChainClient) QueryABCI(ctx context.Context, req abci.RequestQuery) (abci.ResponseQuery, error) {
+	// The proof at a height is checked against the header of the next one, so the latest
+	// height can't be proven yet
+	if req.Prove && req.Height <= 0 && isQueryStoreWithProof(req.Path) {
+		status, err := cc.RPCClient.Status(ctx)
+		if err != nil {
+			return abci.ResponseQuery{}, err
+		}
+		req.Height = status.SyncInfo.LatestBlockHeight - 1
+	}
 	opts := rpcclient.ABCIQueryOptions{
 		Height: req.Height,
 		Prove:  req.Prove,
@@ -247,6 +368,9 @@ func (cc *ChainClient) QueryABCI(ctx context.Context, req abci.RequestQuery) (ab
 		return result.Response, nil
 	}
 
+	if err := cc.verifyQueryProof(ctx, req.Path, result.Response); err != nil {
+		return abci.ResponseQuery{}, err
+	}
 	return result.Response, nil
 }
 
@@ -0,0 +1,61 @@
+package client
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TxOption overrides the chain configuration for a single transaction.
+type TxOption func(*txOptions)
+
+type txOptions struct {
+	broadcastMode string
+	gas           uint64
+	feeGranter    sdk.AccAddress
+	feePayer      sdk.AccAddress
+}
+
+// txOptionsFromConfig returns the options configured on the chain with opts applied on top.
+func (cc *ChainClient) txOptionsFromConfig(opts ...TxOption) txOptions {
+	o := txOptions{
+		broadcastMode: cc.Config.BroadcastMode,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.broadcastMode == "" {
+		o.broadcastMode = BroadcastModeCommit
+	}
+	return o
+}
+
+// WithBroadcastMode sets how long broadcasting waits, see the BroadcastMode constants.
+func WithBroadcastMode(mode string) TxOption {
+	return func(o *txOptions) {
+		o.broadcastMode = mode
+	}
+}
+
+// WithGas sets the gas limit of the transaction instead of simulating it.
+func WithGas(gas uint64) TxOption {
+	return func(o *txOptions) {
+		o.gas = gas
+	}
+}
+
+// WithFeeGranter pays the fees of the transaction from a fee allowance granted by
+// granter to the fee payer.
+func WithFeeGranter(granter sdk.AccAddress) TxOption {
+	return func(o *txOptions) {
+		o.feeGranter = granter
+	}
+}
+
+// WithFeePayer pays the fees of the transaction from payer instead of the first
+// signer. The fee payer has to sign the transaction too, so unless it is the
+// signing key, build the transaction with BuildUnsignedTx and sign it with SignTx.
+// The gas of such a transaction can't be simulated, set it with WithGas.
+func WithFeePayer(payer sdk.AccAddress) TxOption {
+	return func(o *txOptions) {
+		o.feePayer = payer
+	}
+}
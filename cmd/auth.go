@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	tmquery "github.com/cosmos/cosmos-sdk/types/query"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client/query"
 )
 
 func authAccountCmd(a *appState) *cobra.Command {
@@ -42,16 +45,22 @@ func authAccountsCmd(a *appState) *cobra.Command {
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cl := a.Config.GetDefaultClient()
-			pr, err := ReadPageRequest(cmd.Flags())
+			opts, err := queryOptionsFromFlags(cmd.Flags())
 			if err != nil {
 				return err
 			}
-			req := &authtypes.QueryAccountsRequest{Pagination: pr}
-			res, err := authtypes.NewQueryClient(cl).Accounts(cmd.Context(), req)
+			accounts, page, err := query.Paginate(&query.Query{Client: cl, Options: opts}, func(q *query.Query, pr *tmquery.PageRequest) ([]*codectypes.Any, *tmquery.PageResponse, error) {
+				queryClient := authtypes.NewQueryClient(q)
+				res, err := queryClient.Accounts(cmd.Context(), &authtypes.QueryAccountsRequest{Pagination: pr})
+				if err != nil {
+					return nil, nil, err
+				}
+				return res.Accounts, res.Pagination, nil
+			})
 			if err != nil {
 				return err
 			}
-			return cl.PrintObject(res)
+			return cl.PrintObject(&authtypes.QueryAccountsResponse{Accounts: accounts, Pagination: page})
 		},
 	}
 	return paginationFlags(cmd, a.Viper)
@@ -1,8 +1,24 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tmquery "github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/strangelove-ventures/lens/client/query"
+)
+
+const (
+	flagAllowList         = "allow-list"
+	flagMaxTokens         = "max-tokens"
+	flagAllowedValidators = "allowed-validators"
+	flagDenyValidators    = "deny-validators"
 )
 
 func authzGrantsCmd(a *appState) *cobra.Command {
@@ -17,7 +33,7 @@ func authzGrantsCmd(a *appState) *cobra.Command {
 			// ledger as the grantor (i.e. cosmoshub-ledger in the config)
 			// and test keyringbacked for the grantee (i.e. cosmoshub)
 			cl := a.Config.GetDefaultClient()
-			pageReq, err := ReadPageRequest(cmd.Flags())
+			opts, err := queryOptionsFromFlags(cmd.Flags())
 			if err != nil {
 				return err
 			}
@@ -37,56 +53,229 @@ func authzGrantsCmd(a *appState) *cobra.Command {
 				// TODO: input validation for msg_type
 				MsgTypeUrl = args[2]
 			}
-			req := &authz.QueryGrantsRequest{
-				Granter:    cl.MustEncodeAccAddr(orAddr),
-				Grantee:    cl.MustEncodeAccAddr(eeAddr),
-				MsgTypeUrl: MsgTypeUrl,
-				Pagination: pageReq,
-			}
-
-			res, err := authz.NewQueryClient(cl).Grants(cmd.Context(), req)
+			grants, page, err := query.Paginate(&query.Query{Client: cl, Options: opts}, func(q *query.Query, pr *tmquery.PageRequest) ([]*authz.Grant, *tmquery.PageResponse, error) {
+				queryClient := authz.NewQueryClient(q)
+				res, err := queryClient.Grants(cmd.Context(), &authz.QueryGrantsRequest{
+					Granter:    cl.MustEncodeAccAddr(orAddr),
+					Grantee:    cl.MustEncodeAccAddr(eeAddr),
+					MsgTypeUrl: MsgTypeUrl,
+					Pagination: pr,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				return res.Grants, res.Pagination, nil
+			})
 			if err != nil {
 				return err
 			}
 
-			return cl.PrintObject(res)
+			return cl.PrintObject(&authz.QueryGrantsResponse{Grants: grants, Pagination: page})
 		},
 	}
 	return paginationFlags(cmd, a.Viper)
 }
 
-// TODO: rethink UX here. We should break this up into a number
-// of smaller commands. For example, we should have a grantSend,
-// grantStake, grantWithdraw, grantVote, grantValidator, etc...
-// authzGrantAuthorizationCmd returns the authz grant authorization command for this module
+// authzGrantAuthorizationCmd returns the authz grant authorization commands for this module
 func authzGrantAuthorizationCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "grant [grantor] [grantee] [role]",
-		Aliases: []string{"grant"},
-		Short:   "grant an authorization",
+		Use:     "grant",
+		Aliases: []string{"g"},
+		Short:   "grant an authorization to execute messages on behalf of the granter",
+		Long: strings.TrimSpace(`Grant an authorization, from the default key if no granter is given.
+The grantee can then execute the authorized messages with "lens tx authz exec".`),
+	}
+
+	cmd.AddCommand(
+		authzGrantGenericCmd(a),
+		authzGrantSendCmd(a),
+		authzGrantStakeCmd(a),
+	)
+
+	cmd.PersistentFlags().String(flagExpiration, "", "RFC 3339 timestamp after which the authorization expires, never if not set")
+	return cmd
+}
+
+// authzGrantGenericCmd returns the command to grant a generic authorization
+func authzGrantGenericCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generic [grantee] [msg_type] [granter]?",
+		Args:  cobra.RangeArgs(2, 3),
+		Short: "grant an authorization to execute any message of a type",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx authz grant generic cosmos1... /cosmos.gov.v1.MsgVote mykey --expiration 2024-01-01T00:00:00Z`, appName)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// TODO: allow the user to specify the grantor client
-			// and the grantee client. This will allow for use of a
-			// ledger as the grantor (i.e. cosmoshub-ledger in the config)
-			// and test keyringbacked for the grantee (i.e. cosmoshub)
+			var granter string
+			if len(args) == 3 {
+				granter = args[2]
+			}
+			return sendGrant(cmd, a, args[0], granter, authz.NewGenericAuthorization(args[1]))
+		},
+	}
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// authzGrantSendCmd returns the command to grant a send authorization
+func authzGrantSendCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send [grantee] [granter]?",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "grant an authorization to send coins up to a spend limit",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx authz grant send cosmos1... mykey --spend-limit 1000uatom
+$ %s tx authz grant send cosmos1... mykey --spend-limit 1000uatom --allow-list cosmos1...,cosmos1...`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			limit, _ := cmd.Flags().GetString(flagSpendLimit)
+			spendLimit, err := sdk.ParseCoinsNormalized(limit)
+			if err != nil {
+				return fmt.Errorf("parsing spend limit: %w", err)
+			}
+			authorization := &banktypes.SendAuthorization{SpendLimit: spendLimit}
+			allowList, _ := cmd.Flags().GetStringSlice(flagAllowList)
+			for _, addr := range allowList {
+				acc, err := cl.DecodeBech32AccAddr(addr)
+				if err != nil {
+					return fmt.Errorf("invalid allowed address %s: %w", addr, err)
+				}
+				authorization.AllowList = append(authorization.AllowList, cl.MustEncodeAccAddr(acc))
+			}
+
+			var granter string
+			if len(args) == 2 {
+				granter = args[1]
+			}
+			return sendGrant(cmd, a, args[0], granter, authorization)
+		},
+	}
+	cmd.Flags().String(flagSpendLimit, "", "maximum amount of coins the grantee can send")
+	cmd.Flags().StringSlice(flagAllowList, nil, "comma separated addresses the grantee can send to, any if not set")
+	if err := cmd.MarkFlagRequired(flagSpendLimit); err != nil {
+		panic(err)
+	}
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// authzGrantStakeCmd returns the command to grant a delegate, unbond or redelegate authorization
+func authzGrantStakeCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stake [delegate|unbond|redelegate] [grantee] [granter]?",
+		Args:  cobra.RangeArgs(2, 3),
+		Short: "grant an authorization to delegate, unbond or redelegate",
+		Long: strings.TrimSpace(`Grant an authorization to delegate, unbond or redelegate, optionally up to --max-tokens.
+Either the validators allowed with --allowed-validators or those not denied with
+--deny-validators can be staked with.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx authz grant stake delegate cosmos1... mykey --allowed-validators cosmosvaloper1...,cosmosvaloper1...
+$ %s tx authz grant stake unbond cosmos1... mykey --deny-validators cosmosvaloper1... --max-tokens 1000uatom`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			var authzType stakingtypes.AuthorizationType
+			switch args[0] {
+			case "delegate":
+				authzType = stakingtypes.AuthorizationType_AUTHORIZATION_TYPE_DELEGATE
+			case "unbond":
+				authzType = stakingtypes.AuthorizationType_AUTHORIZATION_TYPE_UNDELEGATE
+			case "redelegate":
+				authzType = stakingtypes.AuthorizationType_AUTHORIZATION_TYPE_REDELEGATE
+			default:
+				return fmt.Errorf("invalid stake authorization type %s, expected delegate, unbond or redelegate", args[0])
+			}
+			authorization := &stakingtypes.StakeAuthorization{AuthorizationType: authzType}
+
+			if maxStr, _ := cmd.Flags().GetString(flagMaxTokens); maxStr != "" {
+				maxTokens, err := sdk.ParseCoinNormalized(maxStr)
+				if err != nil {
+					return fmt.Errorf("parsing max tokens: %w", err)
+				}
+				authorization.MaxTokens = &maxTokens
+			}
 
-			// cl := config.GetDefaultClient()
-			// var authorization authz.Authorization
-			// msg := &authz.MsgGrant{
-			// 	Granter: "cosmos1y54exmx84cqtasvjnskf9f63djuuj68p7hqf47",
-			// 	Grantee: "cosmos1y54exmx84cqtasvjnskf9f63djuuj68p7hqf47",
-			// 	Grant: authz.Grant{
-			// 		Authorization: authorization,
-			// 		Expiration:    0,
-			// 	},
-			// }
-			// return cl.HandleAndPrintMsgSend(cl.SendMsg(cmd.Context(), msg))
-			return nil
+			allowed, _ := cmd.Flags().GetStringSlice(flagAllowedValidators)
+			denied, _ := cmd.Flags().GetStringSlice(flagDenyValidators)
+			switch {
+			case len(allowed) > 0 && len(denied) > 0:
+				return fmt.Errorf("only one of --%s and --%s can be set", flagAllowedValidators, flagDenyValidators)
+			case len(allowed) > 0:
+				validators, err := validatorList(cl, allowed)
+				if err != nil {
+					return err
+				}
+				authorization.Validators = &stakingtypes.StakeAuthorization_AllowList{AllowList: validators}
+			case len(denied) > 0:
+				validators, err := validatorList(cl, denied)
+				if err != nil {
+					return err
+				}
+				authorization.Validators = &stakingtypes.StakeAuthorization_DenyList{DenyList: validators}
+			default:
+				return fmt.Errorf("one of --%s and --%s must be set", flagAllowedValidators, flagDenyValidators)
+			}
+
+			var granter string
+			if len(args) == 3 {
+				granter = args[2]
+			}
+			return sendGrant(cmd, a, args[1], granter, authorization)
 		},
 	}
+	cmd.Flags().String(flagMaxTokens, "", "maximum amount of tokens the grantee can stake, unlimited if not set")
+	cmd.Flags().StringSlice(flagAllowedValidators, nil, "comma separated validators the grantee can stake with")
+	cmd.Flags().StringSlice(flagDenyValidators, nil, "comma separated validators the grantee cannot stake with")
+	memoFlag(a.Viper, cmd)
 	return cmd
 }
 
+// validatorList decodes validator operator addresses into a stake authorization validator list.
+func validatorList(cl *client.ChainClient, addrs []string) (*stakingtypes.StakeAuthorization_Validators, error) {
+	validators := &stakingtypes.StakeAuthorization_Validators{}
+	for _, addr := range addrs {
+		valAddr, err := cl.DecodeBech32ValAddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator address %s: %w", addr, err)
+		}
+		validators.Address = append(validators.Address, cl.MustEncodeValAddr(valAddr))
+	}
+	return validators, nil
+}
+
+// sendGrant grants authorization to grantee from the key or address granter, the default
+// key if empty, expiring at --expiration.
+func sendGrant(cmd *cobra.Command, a *appState, grantee, granter string, authorization authz.Authorization) error {
+	cl := a.Config.GetDefaultClient()
+	orAddr, err := cl.AccountFromKeyOrAddress(granter)
+	if err != nil {
+		return err
+	}
+	eeAddr, err := cl.DecodeBech32AccAddr(grantee)
+	if err != nil {
+		return err
+	}
+	if err := authorization.ValidateBasic(); err != nil {
+		return err
+	}
+	expiration, err := expirationFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	memo, err := cmd.Flags().GetString(flagMemo)
+	if err != nil {
+		return err
+	}
+
+	msg := &authz.MsgGrant{
+		Granter: cl.MustEncodeAccAddr(orAddr),
+		Grantee: cl.MustEncodeAccAddr(eeAddr),
+		Grant:   authz.Grant{Expiration: expiration},
+	}
+	if err := msg.SetAuthorization(authorization); err != nil {
+		return err
+	}
+	return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+}
+
 // authzRevokeAuthorizationCmd returns the authz revoke authorization command for this module
 func authzRevokeAuthorizationCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
@@ -124,7 +313,7 @@ func authzRevokeAuthorizationCmd(a *appState) *cobra.Command {
 				Grantee:    cl.MustEncodeAccAddr(eeAddr),
 				MsgTypeUrl: args[1],
 			}
-			return cl.HandleAndPrintMsgSend(cl.SendMsg(cmd.Context(), msg, memo))
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
 		},
 	}
 	memoFlag(a.Viper, cmd)
@@ -136,29 +325,39 @@ func authzExecAuthorizationCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "exec [msg_tx_json_file] [grantee]?",
 		Aliases: []string{"exec"},
-		Short:   "execute an authorization",
-		Args:    cobra.RangeArgs(1, 2),
+		Short:   "execute the messages of a transaction with the authorizations granted to the grantee",
+		Long: strings.TrimSpace(`Execute the messages of an unsigned transaction, as generated with --generate-only
+by the granter, with the authorizations granted to the grantee. The messages are wrapped
+in a MsgExec signed by the grantee, the default key if none is given.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx bank send cosmos1granter... cosmos1... 100uatom --generate-only --gas 100000 > send.json
+$ %s tx authz exec send.json grantee`, appName, appName)),
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// TODO: should we expose this to the user?
-			// is there a way to check in the other transaction commands
-			// if the sender is a grantee and then wrap their messages in
-			// execute messages?
-
-			// cl := config.GetDefaultClient()
-			// var key string
-			// if len(args) == 2 {
-			// 	key = args[1]
-			// }
-			// sender, err := cl.AccountFromKeyOrAddress(key)
-			// if err != nil {
-			// 	return err
-			// }
-			// msg := &authz.MsgExec{
-			// 	Grantee: cl.MustEncodeAccAddr(sender),
-			// 	Msgs:    []authz.Msg{},
-			// }
-			return nil
+			cl := a.Config.GetDefaultClient()
+			var key string
+			if len(args) == 2 {
+				key = args[1]
+			}
+			grantee, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			tx, err := readTxFile(cl, args[0])
+			if err != nil {
+				return err
+			}
+			msg, err := cl.NewMsgExec(grantee, tx.GetMsgs())
+			if err != nil {
+				return err
+			}
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
 		},
 	}
+	memoFlag(a.Viper, cmd)
 	return cmd
 }
@@ -0,0 +1,66 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTxAuthzGrant_GenerateOnly(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+
+	grant := func(args ...string) map[string]interface{} {
+		t.Helper()
+		msgs := generateOnlyMsgs(t, sys, append([]string{"tx", "authz", "grant"}, args...)...)
+		require.Len(t, msgs, 1)
+		require.Equal(t, "/cosmos.authz.v1beta1.MsgGrant", msgs[0]["@type"])
+		return msgs[0]["grant"].(map[string]interface{})
+	}
+
+	generic := grant("generic", testGrantee, "/cosmos.gov.v1.MsgVote", "--expiration", "2100-01-01T00:00:00Z")
+	require.Equal(t, "2100-01-01T00:00:00Z", generic["expiration"])
+	require.Equal(t, "/cosmos.gov.v1.MsgVote", generic["authorization"].(map[string]interface{})["msg"])
+
+	send := grant("send", testGrantee, "--spend-limit", "100uatom", "--allow-list", testGrantee)["authorization"].(map[string]interface{})
+	require.Equal(t, "/cosmos.bank.v1beta1.SendAuthorization", send["@type"])
+	require.Equal(t, []interface{}{testGrantee}, send["allow_list"])
+
+	stake := grant("stake", "unbond", testGrantee, "--deny-validators", testValoper, "--max-tokens", "10uatom")["authorization"].(map[string]interface{})
+	require.Equal(t, "/cosmos.staking.v1beta1.StakeAuthorization", stake["@type"])
+	require.Equal(t, "AUTHORIZATION_TYPE_UNDELEGATE", stake["authorization_type"])
+	require.Equal(t, []interface{}{testValoper}, stake["deny_list"].(map[string]interface{})["address"])
+
+	res := sys.Run(zaptest.NewLogger(t), "tx", "authz", "grant", "stake", "delegate", testGrantee, "--generate-only", "--gas", "100000")
+	require.ErrorContains(t, res.Err, "must be set")
+	res = sys.Run(zaptest.NewLogger(t), "tx", "authz", "grant", "stake", "vote", testGrantee, "--allowed-validators", testValoper, "--generate-only", "--gas", "100000")
+	require.ErrorContains(t, res.Err, "invalid stake authorization type")
+}
+
+func TestTxAuthzExec_GenerateOnly(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+	sys.MustRun(t, "keys", "add", "grantee")
+	res := sys.MustRun(t, "keys", "show", "grantee")
+	grantee := strings.TrimSpace(res.Stdout.String())
+
+	res = sys.MustRun(t, "tx", "bank", "send", "default", testGrantee, "1uatom", "--generate-only", "--gas", "100000")
+	path := filepath.Join(t.TempDir(), "send.json")
+	require.NoError(t, os.WriteFile(path, res.Stdout.Bytes(), 0600))
+
+	msgs := generateOnlyMsgs(t, sys, "tx", "authz", "exec", path, "grantee")
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.authz.v1beta1.MsgExec", msgs[0]["@type"])
+	require.Equal(t, grantee, msgs[0]["grantee"])
+	execMsgs := msgs[0]["msgs"].([]interface{})
+	require.Len(t, execMsgs, 1)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", execMsgs[0].(map[string]interface{})["@type"])
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -43,7 +44,16 @@ func bankSendCmd(a *appState) *cobra.Command {
 				return err
 			}
 
-			res, err := cl.SendMsg(cmd.Context(), req, memo)
+			if generateOnly, _ := cmd.Flags().GetBool(flagGenerateOnly); generateOnly {
+				return sendMsgs(cmd, cl, []sdk.Msg{req}, memo)
+			}
+
+			opts, err := txOptionsFromFlags(cmd, cl)
+			if err != nil {
+				return err
+			}
+
+			res, err := cl.SendMsg(cmd.Context(), req, memo, opts...)
 			if err != nil {
 				if res != nil {
 					return fmt.Errorf("failed to send coins: code(%d) msg(%s)", res.Code, res.Logs)
@@ -68,11 +78,7 @@ func bankBalanceCmd(a *appState) *cobra.Command {
 		Args:    cobra.RangeArgs(0, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cl := a.Config.GetDefaultClient()
-			pr, err := ReadPageRequest(cmd.Flags())
-			if err != nil {
-				return err
-			}
-			height, err := ReadHeight(cmd.Flags())
+			opts, err := queryOptionsFromFlags(cmd.Flags())
 			if err != nil {
 				return err
 			}
@@ -87,8 +93,7 @@ func bankBalanceCmd(a *appState) *cobra.Command {
 				return err
 			}
 			encodedAddr := cl.MustEncodeAccAddr(address)
-			options := query.QueryOptions{Pagination: pr, Height: height}
-			query := query.Query{Client: cl, Options: &options}
+			query := query.Query{Client: cl, Options: opts}
 			balance, err := query.Bank_Balances(encodedAddr)
 			if err != nil {
 				return err
@@ -97,7 +102,54 @@ func bankBalanceCmd(a *appState) *cobra.Command {
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	flags.AddPaginationFlagsToCmd(cmd, "balance")
+	return paginationFlags(cmd, a.Viper)
+}
+
+// bankVerifiedBalanceCmd returns the command to query a balance proven against a header
+// verified by the light client
+func bankVerifiedBalanceCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verified-balance [denom] [key-or-address]?",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "query the balance of a denom with a merkle proof verified against a light client header",
+		Long: strings.TrimSpace(`Query the balance of a denom of a key or address, or of the default key, without trusting the node.
+The balance is proven against the app hash of a header verified by a light client, which
+trusts the trusted-height and trusted-hash of the chain configuration.
+
+The light client cross-checks headers with the RPC addresses of the light-witnesses of the
+chain configuration, which should be other nodes than the rpc-addr. Without witnesses the
+headers are only checked against the rpc-addr itself, which can't detect a node serving a
+fork, and a warning is logged.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains edit cosmoshub trusted-height 15000000
+$ %s chains edit cosmoshub trusted-hash 0B1A...
+$ %s chains edit cosmoshub light-witnesses https://rpc-a.example.com:443,https://rpc-b.example.com:443
+$ %s query bank verified-balance uatom cosmos1...`, appName, appName, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			key := cl.Config.Key
+			if len(args) == 2 {
+				key = args[1]
+			}
+			address, err := addressFromKeyOrAddress(cl, key)
+			if err != nil {
+				return err
+			}
+			height, err := ReadHeight(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			balance, height, err := cl.QueryVerifiedBalance(cmd.Context(), address, args[0], height)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(struct {
+				Balance sdk.Coin `json:"balance"`
+				Height  int64    `json:"height"`
+			}{balance, height})
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
@@ -109,16 +161,11 @@ func bankTotalSupplyCmd(a *appState) *cobra.Command {
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cl := a.Config.GetDefaultClient()
-			pr, err := ReadPageRequest(cmd.Flags())
+			opts, err := queryOptionsFromFlags(cmd.Flags())
 			if err != nil {
 				return err
 			}
-			height, err := ReadHeight(cmd.Flags())
-			if err != nil {
-				return err
-			}
-			options := query.QueryOptions{Pagination: pr, Height: height}
-			query := query.Query{Client: cl, Options: &options}
+			query := query.Query{Client: cl, Options: opts}
 			totalSupply, err := query.Bank_TotalSupply()
 			if err != nil {
 				return err
@@ -127,8 +174,7 @@ func bankTotalSupplyCmd(a *appState) *cobra.Command {
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	flags.AddPaginationFlagsToCmd(cmd, "total-supply")
-	return cmd
+	return paginationFlags(cmd, a.Viper)
 }
 
 func bankDenomsMetadataCmd(a *appState) *cobra.Command {
@@ -139,16 +185,11 @@ func bankDenomsMetadataCmd(a *appState) *cobra.Command {
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cl := a.Config.GetDefaultClient()
-			pr, err := ReadPageRequest(cmd.Flags())
+			opts, err := queryOptionsFromFlags(cmd.Flags())
 			if err != nil {
 				return err
 			}
-			height, err := ReadHeight(cmd.Flags())
-			if err != nil {
-				return err
-			}
-			options := query.QueryOptions{Pagination: pr, Height: height}
-			query := query.Query{Client: cl, Options: &options}
+			query := query.Query{Client: cl, Options: opts}
 			denoms, err := query.Bank_DenomsMetadata()
 			if err != nil {
 				return err
@@ -157,6 +198,5 @@ func bankDenomsMetadataCmd(a *appState) *cobra.Command {
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	flags.AddPaginationFlagsToCmd(cmd, "denoms-metadata")
-	return cmd
+	return paginationFlags(cmd, a.Viper)
 }
@@ -0,0 +1,22 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestQueryBankVerifiedBalance_NoTrustedHeader(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+
+	res := sys.Run(zaptest.NewLogger(t), "query", "bank", "verified-balance", "uatom")
+	require.ErrorContains(t, res.Err, "no trusted-height and trusted-hash configured")
+
+	sys.MustRun(t, "chains", "edit", "cosmoshub", "trusted-height", "15000000")
+	res = sys.Run(zaptest.NewLogger(t), "query", "bank", "verified-balance", "uatom")
+	require.ErrorContains(t, res.Err, "invalid trusted header")
+}
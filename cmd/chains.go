@@ -142,6 +142,10 @@ func cmdChainsEdit(a *appState) *cobra.Command {
 				a.Config.Chains[args[0]].RPCAddr = args[2]
 			case "grpc-addr":
 				a.Config.Chains[args[0]].GRPCAddr = args[2]
+			case "rpc-addrs":
+				a.Config.Chains[args[0]].RPCAddrs = splitAddrs(args[2])
+			case "grpc-addrs":
+				a.Config.Chains[args[0]].GRPCAddrs = splitAddrs(args[2])
 			case "account-prefix":
 				a.Config.Chains[args[0]].AccountPrefix = args[2]
 			case "gas-adjustment":
@@ -166,8 +170,20 @@ func cmdChainsEdit(a *appState) *cobra.Command {
 				a.Config.Chains[args[0]].Debug = b
 			case "timeout":
 				a.Config.Chains[args[0]].Timeout = args[2]
+			case "trusted-height":
+				h, err := strconv.ParseInt(args[2], 10, 64)
+				if err != nil {
+					return err
+				}
+				a.Config.Chains[args[0]].TrustedHeight = h
+			case "trusted-hash":
+				a.Config.Chains[args[0]].TrustedHash = args[2]
+			case "trusting-period":
+				a.Config.Chains[args[0]].TrustingPeriod = args[2]
+			case "light-witnesses":
+				a.Config.Chains[args[0]].LightWitnesses = splitAddrs(args[2])
 			default:
-				return fmt.Errorf("unknown key %s, try 'key', 'chain-id', 'rpc-addr', 'grpc-addr', 'account-prefix', 'gas-adjustment', 'gas-prices', 'min-gas-amount', 'debug', or 'timeout'", args[1])
+				return fmt.Errorf("unknown key %s, try 'key', 'chain-id', 'rpc-addr', 'grpc-addr', 'rpc-addrs', 'grpc-addrs', 'account-prefix', 'gas-adjustment', 'gas-prices', 'min-gas-amount', 'debug', 'timeout', 'trusted-height', 'trusted-hash', 'trusting-period' or 'light-witnesses'", args[1])
 			}
 			return a.OverwriteConfig(a.Config)
 		},
@@ -175,6 +191,17 @@ func cmdChainsEdit(a *appState) *cobra.Command {
 	return cmd
 }
 
+// splitAddrs splits a comma separated list of addresses, the empty string is no addresses.
+func splitAddrs(s string) []string {
+	var addrs []string
+	for _, addr := range strings.Split(s, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
 func cmdChainsList(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list",
@@ -101,3 +101,34 @@ func TestChainEdit_Show(t *testing.T) {
 		cmp.Diff(before, after, cmpopts.IgnoreFields(client.ChainClientConfig{}, "Timeout")),
 	)
 }
+
+func TestChainEdit_RPCAddrs(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+
+	sys.MustRun(t, "chains", "edit", "cosmoshub", "rpc-addrs", "https://rpc-1.example.com:443, https://rpc-2.example.com:443")
+
+	var after client.ChainClientConfig
+	res := sys.MustRun(t, "chains", "show", "cosmoshub")
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &after))
+	require.Equal(t, []string{"https://rpc-1.example.com:443", "https://rpc-2.example.com:443"}, after.RPCAddrs)
+
+	// The empty string clears the addresses
+	sys.MustRun(t, "chains", "edit", "cosmoshub", "rpc-addrs", "")
+	res = sys.MustRun(t, "chains", "show", "cosmoshub")
+	require.NotContains(t, res.Stdout.String(), "rpc-addrs")
+}
+
+func TestChainEdit_LightWitnesses(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+
+	sys.MustRun(t, "chains", "edit", "cosmoshub", "light-witnesses", "https://rpc-1.example.com:443,https://rpc-2.example.com:443")
+
+	var after client.ChainClientConfig
+	res := sys.MustRun(t, "chains", "show", "cosmoshub")
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &after))
+	require.Equal(t, []string{"https://rpc-1.example.com:443", "https://rpc-2.example.com:443"}, after.LightWitnesses)
+}
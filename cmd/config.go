@@ -57,6 +57,17 @@ func (c *Config) GetClient(chainID string) *client.ChainClient {
 	return nil
 }
 
+// closeClients closes the connections of the chain clients, it returns the first error.
+func (c *Config) closeClients() error {
+	var firstErr error
+	for name, cl := range c.cl {
+		if err := cl.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close client of %s: %w", name, err)
+		}
+	}
+	return firstErr
+}
+
 // Called to initialize the relayer.Chain types on Config
 func validateConfig(c *Config) error {
 	for _, chain := range c.Chains {
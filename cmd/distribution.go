@@ -92,7 +92,7 @@ $ lens tx withdraw-rewards --from mykey --all
 				msgs = append(msgs, types.NewMsgWithdrawValidatorCommission(sdk.ValAddress(valAddr)))
 			}
 
-			return cl.HandleAndPrintMsgSend(cl.SendMsgs(cmd.Context(), msgs, memo))
+			return sendMsgs(cmd, cl, msgs, memo)
 		},
 	}
 	cmd.Flags().BoolP(FlagCommission, "c", false, "withdraw commission from a validator")
@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client/query"
+)
+
+const (
+	flagSpendLimit  = "spend-limit"
+	flagExpiration  = "expiration"
+	flagPeriod      = "period"
+	flagPeriodLimit = "period-limit"
+	flagAllowedMsgs = "allowed-messages"
+)
+
+// feegrantGrantCmd returns the command to grant a fee allowance
+func feegrantGrantCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "grant [grantee] [granter]?",
+		Aliases: []string{"g"},
+		Args:    cobra.RangeArgs(1, 2),
+		Short:   "grant a fee allowance to an address",
+		Long: strings.TrimSpace(`Grant an allowance to pay fees from the granter's account, the default key if none is given.
+Without any flags the grantee can spend any amount on fees. With --period and --period-limit
+the allowance is periodic, and with --allowed-messages it only pays for the given messages.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx feegrant grant cosmos1... treasury --spend-limit 1000000uatom --expiration 2024-01-01T00:00:00Z
+$ %s tx feegrant grant cosmos1... treasury --period 24h --period-limit 10000uatom
+$ %s tx feegrant grant cosmos1... treasury --allowed-messages /cosmos.bank.v1beta1.MsgSend`, appName, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			var key string
+			if len(args) == 2 {
+				key = args[1]
+			}
+			granter, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			grantee, err := cl.DecodeBech32AccAddr(args[0])
+			if err != nil {
+				return err
+			}
+
+			allowance, err := feeAllowanceFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			msg := &feegrant.MsgGrantAllowance{
+				Granter:   cl.MustEncodeAccAddr(granter),
+				Grantee:   cl.MustEncodeAccAddr(grantee),
+				Allowance: allowance,
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+	cmd.Flags().String(flagSpendLimit, "", "maximum amount of coins the grantee can spend on fees, unlimited if not set")
+	cmd.Flags().String(flagExpiration, "", "RFC 3339 timestamp after which the allowance expires")
+	cmd.Flags().Duration(flagPeriod, 0, "duration after which the spendable amount of a periodic allowance is reset to --period-limit")
+	cmd.Flags().String(flagPeriodLimit, "", "maximum amount of coins the grantee can spend on fees per period")
+	cmd.Flags().StringSlice(flagAllowedMsgs, nil, "comma separated type URLs of the messages the allowance pays for, all if not set")
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// feeAllowanceFromFlags builds a basic, periodic or allowed-msg fee allowance from the grant flags.
+func feeAllowanceFromFlags(cmd *cobra.Command) (*codectypes.Any, error) {
+	var basic feegrant.BasicAllowance
+
+	if limit, _ := cmd.Flags().GetString(flagSpendLimit); limit != "" {
+		coins, err := sdk.ParseCoinsNormalized(limit)
+		if err != nil {
+			return nil, fmt.Errorf("parsing spend limit: %w", err)
+		}
+		basic.SpendLimit = coins
+	}
+
+	expiration, err := expirationFromFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+	basic.Expiration = expiration
+
+	var allowance feegrant.FeeAllowanceI = &basic
+
+	period, _ := cmd.Flags().GetDuration(flagPeriod)
+	periodLimit, _ := cmd.Flags().GetString(flagPeriodLimit)
+	if period != 0 || periodLimit != "" {
+		if period <= 0 {
+			return nil, fmt.Errorf("--%s must be set to a positive duration for a periodic allowance", flagPeriod)
+		}
+		if periodLimit == "" {
+			return nil, fmt.Errorf("--%s must be set for a periodic allowance", flagPeriodLimit)
+		}
+		coins, err := sdk.ParseCoinsNormalized(periodLimit)
+		if err != nil {
+			return nil, fmt.Errorf("parsing period limit: %w", err)
+		}
+
+		reset := time.Now().Add(period)
+		if basic.Expiration != nil && reset.After(*basic.Expiration) {
+			return nil, fmt.Errorf("period (%s) cannot reset after expiration (%s)", period, basic.Expiration.Format(time.RFC3339))
+		}
+		allowance = &feegrant.PeriodicAllowance{
+			Basic:            basic,
+			Period:           period,
+			PeriodSpendLimit: coins,
+			PeriodCanSpend:   coins,
+			PeriodReset:      reset,
+		}
+	}
+
+	if msgs, _ := cmd.Flags().GetStringSlice(flagAllowedMsgs); len(msgs) > 0 {
+		if allowance, err = feegrant.NewAllowedMsgAllowance(allowance, msgs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := allowance.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	msg, ok := allowance.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("cannot proto marshal %T", allowance)
+	}
+	return codectypes.NewAnyWithValue(msg)
+}
+
+// expirationFromFlags returns the time given by --expiration, or nil if it is not set.
+func expirationFromFlags(cmd *cobra.Command) (*time.Time, error) {
+	exp, _ := cmd.Flags().GetString(flagExpiration)
+	if exp == "" {
+		return nil, nil
+	}
+	expiration, err := time.Parse(time.RFC3339, exp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing expiration: %w", err)
+	}
+	return &expiration, nil
+}
+
+// feegrantRevokeCmd returns the command to revoke a fee allowance
+func feegrantRevokeCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "revoke [grantee] [granter]?",
+		Aliases: []string{"r"},
+		Args:    cobra.RangeArgs(1, 2),
+		Short:   "revoke a fee allowance granted to an address",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx feegrant revoke cosmos1... treasury`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			var key string
+			if len(args) == 2 {
+				key = args[1]
+			}
+			granter, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			grantee, err := cl.DecodeBech32AccAddr(args[0])
+			if err != nil {
+				return err
+			}
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			msg := &feegrant.MsgRevokeAllowance{
+				Granter: cl.MustEncodeAccAddr(granter),
+				Grantee: cl.MustEncodeAccAddr(grantee),
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// ========== Querier Functions ==========
+
+// feegrantGrantQueryCmd returns the command to query the fee allowance between a granter and grantee
+func feegrantGrantQueryCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [granter] [grantee]",
+		Args:  cobra.ExactArgs(2),
+		Short: "query the fee allowance granted by granter to grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			granter, err := addressFromKeyOrAddress(cl, args[0])
+			if err != nil {
+				return err
+			}
+			grantee, err := addressFromKeyOrAddress(cl, args[1])
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Feegrant_Allowance(cl.MustEncodeAccAddr(granter), cl.MustEncodeAccAddr(grantee))
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res.Allowance)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// feegrantGrantsByGranteeCmd returns the command to query the fee allowances granted to an address
+func feegrantGrantsByGranteeCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grants-by-grantee [grantee]?",
+		Args:  cobra.RangeArgs(0, 1),
+		Short: "query the fee allowances granted to an address, the default key if none is given",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			var key string
+			if len(args) == 1 {
+				key = args[0]
+			}
+			grantee, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Feegrant_Allowances(cl.MustEncodeAccAddr(grantee))
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// feegrantGrantsByGranterCmd returns the command to query the fee allowances granted by an address
+func feegrantGrantsByGranterCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grants-by-granter [granter]?",
+		Args:  cobra.RangeArgs(0, 1),
+		Short: "query the fee allowances granted by an address, the default key if none is given",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			var key string
+			if len(args) == 1 {
+				key = args[0]
+			}
+			granter, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Feegrant_AllowancesByGranter(cl.MustEncodeAccAddr(granter))
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
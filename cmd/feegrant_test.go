@@ -0,0 +1,80 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testGrantee = "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"
+
+func TestTxFeeGranter_GenerateOnly(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+	sys.MustRun(t, "keys", "add", "treasury")
+	res := sys.MustRun(t, "keys", "show", "treasury")
+	treasury := strings.TrimSpace(res.Stdout.String())
+
+	res = sys.MustRun(t, "tx", "bank", "send", "default", testGrantee, "1uatom",
+		"--generate-only", "--gas", "100000", "--fee-granter", "treasury")
+	var tx struct {
+		AuthInfo struct {
+			Fee struct {
+				Granter string `json:"granter"`
+			} `json:"fee"`
+		} `json:"auth_info"`
+	}
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &tx))
+	require.Equal(t, treasury, tx.AuthInfo.Fee.Granter)
+
+	res = sys.Run(zaptest.NewLogger(t), "tx", "bank", "send", "default", testGrantee, "1uatom",
+		"--generate-only", "--gas", "100000", "--fee-granter", "nope")
+	require.ErrorContains(t, res.Err, "invalid fee granter")
+}
+
+func TestTxFeePayer_RequiresGas(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+	sys.MustRun(t, "keys", "add", "payer")
+
+	res := sys.Run(zaptest.NewLogger(t), "tx", "bank", "send", "default", testGrantee, "1uatom",
+		"--generate-only", "--fee-payer", "payer")
+	require.ErrorContains(t, res.Err, "set the gas limit (--gas)")
+}
+
+func TestTxFeegrantGrant_Allowances(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+
+	grant := func(flags ...string) map[string]interface{} {
+		t.Helper()
+		msgs := generateOnlyMsgs(t, sys, append([]string{"tx", "feegrant", "grant", testGrantee}, flags...)...)
+		require.Len(t, msgs, 1)
+		return msgs[0]["allowance"].(map[string]interface{})
+	}
+
+	basic := grant("--spend-limit", "100uatom", "--expiration", "2100-01-01T00:00:00Z")
+	require.Equal(t, "/cosmos.feegrant.v1beta1.BasicAllowance", basic["@type"])
+	require.Equal(t, "2100-01-01T00:00:00Z", basic["expiration"])
+
+	periodic := grant("--period", "24h", "--period-limit", "10uatom")
+	require.Equal(t, "/cosmos.feegrant.v1beta1.PeriodicAllowance", periodic["@type"])
+	require.Equal(t, "86400s", periodic["period"])
+
+	allowed := grant("--period", "1h", "--period-limit", "10uatom", "--allowed-messages", "/cosmos.bank.v1beta1.MsgSend")
+	require.Equal(t, "/cosmos.feegrant.v1beta1.AllowedMsgAllowance", allowed["@type"])
+	require.Equal(t, []interface{}{"/cosmos.bank.v1beta1.MsgSend"}, allowed["allowed_messages"])
+	require.Equal(t, "/cosmos.feegrant.v1beta1.PeriodicAllowance", allowed["allowance"].(map[string]interface{})["@type"])
+
+	res := sys.Run(zaptest.NewLogger(t), "tx", "feegrant", "grant", testGrantee, "--generate-only", "--gas", "100000", "--period", "1h")
+	require.ErrorContains(t, res.Err, "--period-limit must be set")
+}
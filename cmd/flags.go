@@ -13,6 +13,29 @@ import (
 const (
 	gRPCSecureOnlyFlag = "secure-only"
 	flagMemo           = "memo"
+	flagBroadcastMode  = "broadcast-mode"
+	flagGenerateOnly   = "generate-only"
+	flagGas            = "gas"
+	flagAccountNumber  = "account-number"
+	flagSequence       = "sequence"
+	flagOffline        = "offline"
+	flagOverwrite      = "overwrite"
+	flagOutputDocument = "output-document"
+	flagMultisig       = "multisig"
+	flagMultisigThresh = "multisig-threshold"
+	flagNoSort         = "nosort"
+	flagFeeGranter     = "fee-granter"
+	flagFeePayer       = "fee-payer"
+	flagPageLimit      = "page-limit"
+	flagAll            = "all"
+	flagRoute          = "route"
+	flagFromHeight     = "from-height"
+	flagHex            = "hex"
+	flagList           = "list"
+	flagWatch          = "watch"
+	flagInterval       = "interval"
+	flagMsgType        = "msg-type"
+	flagSender         = "sender"
 )
 
 func peersFlag(cmd *cobra.Command, v *viper.Viper) *cobra.Command {
@@ -39,6 +62,15 @@ func skipConfirm(cmd *cobra.Command, v *viper.Viper) *cobra.Command {
 	return cmd
 }
 
+// routeFlag adds the flag choosing whether queries go to the gRPC address of the chain or over RPC.
+func routeFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.PersistentFlags().String(flagRoute, string(query.RouteRPC), "send queries as ABCI queries over rpc, over grpc to the grpc-addr of the chain, or prefer-grpc to fall back to rpc when grpc is unavailable")
+	if err := v.BindPFlag(flagRoute, cmd.PersistentFlags().Lookup(flagRoute)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
 func gRPCFlags(cmd *cobra.Command, v *viper.Viper) *cobra.Command {
 	cmd.Flags().Bool(gRPCSecureOnlyFlag, false, "do not fall back to skipping TLS verification when connecting to server")
 	if err := v.BindPFlag(gRPCSecureOnlyFlag, cmd.Flags().Lookup(gRPCSecureOnlyFlag)); err != nil {
@@ -56,6 +88,42 @@ func memoFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
 	return cmd
 }
 
+func broadcastModeFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.PersistentFlags().String(flagBroadcastMode, "", "override the configured broadcast mode (async, sync, commit)")
+	if err := v.BindPFlag(flagBroadcastMode, cmd.PersistentFlags().Lookup(flagBroadcastMode)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+// txBuildFlags adds the flags shared by all tx commands that change how the tx is built.
+func txBuildFlags(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.PersistentFlags().Bool(flagGenerateOnly, false, "print the unsigned transaction as JSON instead of signing and broadcasting it")
+	cmd.PersistentFlags().Uint64(flagGas, 0, "gas limit of the transaction, simulated if not set")
+	cmd.PersistentFlags().String(flagFeeGranter, "", "key or address of an account that granted the fee payer an allowance to pay the fees")
+	cmd.PersistentFlags().String(flagFeePayer, "", "key or address of the account paying the fees instead of the first signer, it has to sign the transaction too and --gas is required unless it is the signer")
+	for _, flag := range []string{flagGenerateOnly, flagGas, flagFeeGranter, flagFeePayer} {
+		if err := v.BindPFlag(flag, cmd.PersistentFlags().Lookup(flag)); err != nil {
+			panic(err)
+		}
+	}
+	return cmd
+}
+
+func signFlags(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Uint64(flagAccountNumber, 0, "account number of the signing account, or of the multisig account when signing for one, required with --offline")
+	cmd.Flags().Uint64(flagSequence, 0, "sequence of the signing account, required with --offline")
+	cmd.Flags().Bool(flagOffline, false, "sign without querying the account number and sequence from the chain")
+	cmd.Flags().Bool(flagOverwrite, false, "replace existing signatures instead of appending to them")
+	cmd.Flags().String(flagOutputDocument, "", "write the signed transaction to this file instead of stdout")
+	for _, flag := range []string{flagAccountNumber, flagSequence, flagOffline, flagOverwrite, flagOutputDocument} {
+		if err := v.BindPFlag(flag, cmd.Flags().Lookup(flag)); err != nil {
+			panic(err)
+		}
+	}
+	return cmd
+}
+
 var (
 	FlagFrom = "from"
 )
@@ -84,6 +152,12 @@ func paginationFlags(cmd *cobra.Command, v *viper.Viper) *cobra.Command {
 
 	cmd.Flags().Bool("reverse", false, "results are sorted in descending order")
 	v.BindPFlag("reverse", cmd.Flags().Lookup("reverse"))
+
+	cmd.Flags().Uint64(flagPageLimit, tmquery.DefaultLimit, "number of objects to query per request, --limit objects are queried with as many requests as needed")
+	v.BindPFlag(flagPageLimit, cmd.Flags().Lookup(flagPageLimit))
+
+	cmd.Flags().Bool(flagAll, false, "query all objects, ignoring --limit")
+	v.BindPFlag(flagAll, cmd.Flags().Lookup(flagAll))
 	return cmd
 }
 
@@ -137,5 +211,20 @@ func queryOptionsFromFlags(flags *pflag.FlagSet) (*query.QueryOptions, error) {
 		return nil, err
 	}
 
-	return &query.QueryOptions{Pagination: pr, Height: height}, nil
+	// The limit is the number of items to collect, the requests for them have the page limit
+	maxItems := pr.Limit
+	if all, _ := flags.GetBool(flagAll); all {
+		maxItems = 0
+	}
+	if pageLimit, _ := flags.GetUint64(flagPageLimit); pageLimit > 0 {
+		pr.Limit = pageLimit
+	}
+
+	routeFlag, _ := flags.GetString(flagRoute)
+	route, err := query.ParseRoute(routeFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.QueryOptions{Pagination: pr, Height: height, MaxItems: maxItems, Route: route}, nil
 }
@@ -0,0 +1,594 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govutils "github.com/cosmos/cosmos-sdk/x/gov/client/utils"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/strangelove-ventures/lens/client/query"
+)
+
+const (
+	flagStatus    = "status"
+	flagVoter     = "voter"
+	flagDepositor = "depositor"
+	flagMetadata  = "metadata"
+)
+
+// proposalFile is the JSON file read by submit-proposal. Proposals with messages
+// are submitted as gov v1 proposals, while a legacy content is submitted with the
+// v1beta1 MsgSubmitProposal.
+type proposalFile struct {
+	Messages []json.RawMessage `json:"messages,omitempty"`
+	Metadata string            `json:"metadata"`
+	Deposit  string            `json:"deposit"`
+	Title    string            `json:"title"`
+	Summary  string            `json:"summary"`
+	Content  json.RawMessage   `json:"content,omitempty"`
+}
+
+// govSubmitProposalCmd returns the command to submit a proposal
+func govSubmitProposalCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "submit-proposal [proposal.json] [key]?",
+		Aliases: []string{"submit", "sp"},
+		Args:    cobra.RangeArgs(1, 2),
+		Short:   "submit a proposal along with an initial deposit",
+		Long: strings.TrimSpace(`Submit a proposal read from a JSON file, from the default key if none is given.
+The messages of the proposal are executed by the gov module account if it passes.
+A proposal without messages is a text proposal.
+
+Instead of messages, the file can have the legacy content of a proposal. It is then
+submitted with the v1beta1 MsgSubmitProposal, which chains without gov v1 support accept too.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx gov submit-proposal proposal.json mykey
+
+Where proposal.json contains:
+{
+  "messages": [
+    {
+      "@type": "/cosmos.bank.v1beta1.MsgSend",
+      "from_address": "cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn",
+      "to_address": "cosmos1hcknvy2yrafm9yhf2hfjl8ff6ry2f5rp6vyxv4",
+      "amount": [{"denom": "uatom", "amount": "10"}]
+    }
+  ],
+  "metadata": "ipfs://CID",
+  "deposit": "10000000uatom",
+  "title": "My proposal",
+  "summary": "A short summary of my proposal"
+}
+
+Or, for a legacy proposal:
+{
+  "content": {
+    "@type": "/cosmos.gov.v1beta1.TextProposal",
+    "title": "My proposal",
+    "description": "A description of my proposal"
+  },
+  "deposit": "10000000uatom"
+}`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			var key string
+			if len(args) == 2 {
+				key = args[1]
+			}
+			proposer, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			msg, err := parseProposal(cl, bz, cl.MustEncodeAccAddr(proposer))
+			if err != nil {
+				return fmt.Errorf("reading proposal from %s: %w", args[0], err)
+			}
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// parseProposal decodes a proposal file into the msg submitting it for proposer.
+func parseProposal(cl *client.ChainClient, bz []byte, proposer string) (sdk.Msg, error) {
+	var p proposalFile
+	if err := json.Unmarshal(bz, &p); err != nil {
+		return nil, err
+	}
+
+	deposit, err := sdk.ParseCoinsNormalized(p.Deposit)
+	if err != nil {
+		return nil, fmt.Errorf("parsing deposit: %w", err)
+	}
+
+	var msg sdk.Msg
+	if len(p.Content) > 0 {
+		if len(p.Messages) > 0 {
+			return nil, fmt.Errorf("a proposal can have either messages or a legacy content, not both")
+		}
+		var content govv1beta1.Content
+		if err := cl.Codec.Marshaler.UnmarshalInterfaceJSON(p.Content, &content); err != nil {
+			return nil, fmt.Errorf("parsing content: %w", err)
+		}
+		legacy := &govv1beta1.MsgSubmitProposal{InitialDeposit: deposit, Proposer: proposer}
+		if err := legacy.SetContent(content); err != nil {
+			return nil, err
+		}
+		msg = legacy
+	} else {
+		msgs := make([]sdk.Msg, len(p.Messages))
+		for i, raw := range p.Messages {
+			if err := cl.Codec.Marshaler.UnmarshalInterfaceJSON(raw, &msgs[i]); err != nil {
+				return nil, fmt.Errorf("parsing message %d: %w", i, err)
+			}
+		}
+		if msg, err = govv1.NewMsgSubmitProposal(msgs, deposit, proposer, p.Metadata, p.Title, p.Summary); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// govDepositCmd returns the command to deposit on a proposal
+func govDepositCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "deposit [proposal-id] [amount] [key]?",
+		Aliases: []string{"d"},
+		Args:    cobra.RangeArgs(2, 3),
+		Short:   "deposit tokens on a proposal in its deposit period",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx gov deposit 1 10000000uatom mykey`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoinsNormalized(args[1])
+			if err != nil {
+				return err
+			}
+			var key string
+			if len(args) == 3 {
+				key = args[2]
+			}
+			depositor, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			msg := &govv1.MsgDeposit{
+				ProposalId: id,
+				Depositor:  cl.MustEncodeAccAddr(depositor),
+				Amount:     amount,
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// govVoteCmd returns the command to vote on a proposal
+func govVoteCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vote [proposal-id] [option] [key]?",
+		Aliases: []string{"v"},
+		Args:    cobra.RangeArgs(2, 3),
+		Short:   "vote on a proposal in its voting period, option is one of yes, no, no_with_veto or abstain",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx gov vote 1 yes mykey`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			option, err := govv1.VoteOptionFromString(govutils.NormalizeVoteOption(args[1]))
+			if err != nil {
+				return err
+			}
+			var key string
+			if len(args) == 3 {
+				key = args[2]
+			}
+			voter, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			metadata, _ := cmd.Flags().GetString(flagMetadata)
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			msg := &govv1.MsgVote{
+				ProposalId: id,
+				Voter:      cl.MustEncodeAccAddr(voter),
+				Option:     option,
+				Metadata:   metadata,
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+	cmd.Flags().String(flagMetadata, "", "metadata of the vote")
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// govWeightedVoteCmd returns the command to split a vote on a proposal between options
+func govWeightedVoteCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "weighted-vote [proposal-id] [options] [key]?",
+		Aliases: []string{"wv"},
+		Args:    cobra.RangeArgs(2, 3),
+		Short:   "vote on a proposal with comma separated option=weight pairs, the weights adding up to 1",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx gov weighted-vote 1 yes=0.6,no=0.3,abstain=0.1 mykey`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			options, err := govv1.WeightedVoteOptionsFromString(govutils.NormalizeWeightedVoteOptions(args[1]))
+			if err != nil {
+				return err
+			}
+			var key string
+			if len(args) == 3 {
+				key = args[2]
+			}
+			voter, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			metadata, _ := cmd.Flags().GetString(flagMetadata)
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			msg := &govv1.MsgVoteWeighted{
+				ProposalId: id,
+				Voter:      cl.MustEncodeAccAddr(voter),
+				Options:    options,
+				Metadata:   metadata,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+	cmd.Flags().String(flagMetadata, "", "metadata of the vote")
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// parseProposalID parses a proposal id argument.
+func parseProposalID(arg string) (uint64, error) {
+	id, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("proposal id %s is not a positive integer", arg)
+	}
+	return id, nil
+}
+
+// parseProposalStatus parses a proposal status such as voting_period, VotingPeriod or
+// PROPOSAL_STATUS_VOTING_PERIOD. The empty string matches proposals of any status.
+func parseProposalStatus(status string) (govv1.ProposalStatus, error) {
+	if status == "" {
+		return govv1.StatusNil, nil
+	}
+	status = strings.ToUpper(govutils.NormalizeProposalStatus(status))
+	if !strings.HasPrefix(status, "PROPOSAL_STATUS_") {
+		status = "PROPOSAL_STATUS_" + status
+	}
+	return govv1.ProposalStatusFromString(status)
+}
+
+// ========== Querier Functions ==========
+
+// govProposalCmd returns the command to query a proposal
+func govProposalCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposal [proposal-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query a proposal, with its messages decoded",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Gov_Proposal(id)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res.Proposal)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// govProposalsCmd returns the command to query proposals
+func govProposalsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposals",
+		Args:  cobra.NoArgs,
+		Short: "query proposals, optionally filtered by status, voter and depositor",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query gov proposals --status voting_period
+$ %s query gov proposals --status passed --voter mykey`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			statusFlag, _ := cmd.Flags().GetString(flagStatus)
+			status, err := parseProposalStatus(statusFlag)
+			if err != nil {
+				return err
+			}
+			var voter, depositor string
+			if v, _ := cmd.Flags().GetString(flagVoter); v != "" {
+				addr, err := addressFromKeyOrAddress(cl, v)
+				if err != nil {
+					return err
+				}
+				voter = cl.MustEncodeAccAddr(addr)
+			}
+			if d, _ := cmd.Flags().GetString(flagDepositor); d != "" {
+				addr, err := addressFromKeyOrAddress(cl, d)
+				if err != nil {
+					return err
+				}
+				depositor = cl.MustEncodeAccAddr(addr)
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Gov_Proposals(status, voter, depositor)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	cmd.Flags().String(flagStatus, "", "only proposals with this status: deposit_period, voting_period, passed, rejected or failed")
+	cmd.Flags().String(flagVoter, "", "only proposals voted on by this key or address")
+	cmd.Flags().String(flagDepositor, "", "only proposals deposited on by this key or address")
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// govVoteQueryCmd returns the command to query a vote on a proposal
+func govVoteQueryCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote [proposal-id] [voter]?",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "query the vote of an address on a proposal, the default key if none is given",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			var key string
+			if len(args) == 2 {
+				key = args[1]
+			}
+			voter, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Gov_Vote(id, cl.MustEncodeAccAddr(voter))
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res.Vote)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// govVotesCmd returns the command to query the votes on a proposal
+func govVotesCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "votes [proposal-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the votes on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Gov_Votes(id)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// govDepositQueryCmd returns the command to query a deposit on a proposal
+func govDepositQueryCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit [proposal-id] [depositor]?",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "query the deposit of an address on a proposal, the default key if none is given",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			var key string
+			if len(args) == 2 {
+				key = args[1]
+			}
+			depositor, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Gov_Deposit(id, cl.MustEncodeAccAddr(depositor))
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res.Deposit)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// govDepositsCmd returns the command to query the deposits on a proposal
+func govDepositsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposits [proposal-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the deposits on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Gov_Deposits(id)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// govTallyCmd returns the command to query the tally of a proposal
+func govTallyCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tally [proposal-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the tally of the votes on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			id, err := parseProposalID(args[0])
+			if err != nil {
+				return err
+			}
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Gov_TallyResult(id)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res.Tally)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// govParamsCmd returns the command to query the gov params
+func govParamsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params [deposit|voting|tallying]?",
+		Args:  cobra.RangeArgs(0, 1),
+		Short: "query the gov params, or only those of the given type",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			if len(args) == 1 {
+				res, err := query.Gov_Params(args[0])
+				if err != nil {
+					return err
+				}
+				return cl.PrintObject(res)
+			}
+
+			// Chains before gov v1 params only return the params of the requested type.
+			params := &govv1.QueryParamsResponse{}
+			for _, paramsType := range []string{govv1.ParamDeposit, govv1.ParamVoting, govv1.ParamTallying} {
+				res, err := query.Gov_Params(paramsType)
+				if err != nil {
+					return err
+				}
+				if res.Params != nil {
+					params.Params = res.Params
+				}
+				switch paramsType {
+				case govv1.ParamDeposit:
+					params.DepositParams = res.DepositParams
+				case govv1.ParamVoting:
+					params.VotingParams = res.VotingParams
+				case govv1.ParamTallying:
+					params.TallyParams = res.TallyParams
+				}
+			}
+			return cl.PrintObject(params)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
@@ -0,0 +1,85 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTxGovVote_GenerateOnly(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+
+	msgs := generateOnlyMsgs(t, sys, "tx", "gov", "vote", "3", "no_with_veto", "--metadata", "because")
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.gov.v1.MsgVote", msgs[0]["@type"])
+	require.Equal(t, "3", msgs[0]["proposal_id"])
+	require.Equal(t, "VOTE_OPTION_NO_WITH_VETO", msgs[0]["option"])
+	require.Equal(t, "because", msgs[0]["metadata"])
+
+	msgs = generateOnlyMsgs(t, sys, "tx", "gov", "weighted-vote", "3", "yes=0.6,abstain=0.4")
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.gov.v1.MsgVoteWeighted", msgs[0]["@type"])
+	require.Len(t, msgs[0]["options"], 2)
+
+	res := sys.Run(zaptest.NewLogger(t), "tx", "gov", "weighted-vote", "3", "yes=0.6,no=0.6", "--generate-only", "--gas", "100000")
+	require.Error(t, res.Err)
+
+	res = sys.Run(zaptest.NewLogger(t), "tx", "gov", "vote", "3", "maybe", "--generate-only", "--gas", "100000")
+	require.Error(t, res.Err)
+}
+
+func TestTxGovSubmitProposal_GenerateOnly(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	v1 := write("v1.json", `{
+  "messages": [{
+    "@type": "/cosmos.bank.v1beta1.MsgSend",
+    "from_address": "cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn",
+    "to_address": "`+testGrantee+`",
+    "amount": [{"denom": "uatom", "amount": "10"}]
+  }],
+  "metadata": "ipfs://CID",
+  "deposit": "10uatom",
+  "title": "Send",
+  "summary": "Send some atoms"
+}`)
+	msgs := generateOnlyMsgs(t, sys, "tx", "gov", "submit-proposal", v1)
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.gov.v1.MsgSubmitProposal", msgs[0]["@type"])
+	require.Equal(t, "Send", msgs[0]["title"])
+	inner := msgs[0]["messages"].([]interface{})
+	require.Len(t, inner, 1)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", inner[0].(map[string]interface{})["@type"])
+
+	legacy := write("legacy.json", `{
+  "content": {"@type": "/cosmos.gov.v1beta1.TextProposal", "title": "Text", "description": "A text proposal"},
+  "deposit": "10uatom"
+}`)
+	msgs = generateOnlyMsgs(t, sys, "tx", "gov", "submit-proposal", legacy)
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.gov.v1beta1.MsgSubmitProposal", msgs[0]["@type"])
+	require.Equal(t, "/cosmos.gov.v1beta1.TextProposal", msgs[0]["content"].(map[string]interface{})["@type"])
+
+	both := write("both.json", `{
+  "messages": [{"@type": "/cosmos.bank.v1beta1.MsgSend"}],
+  "content": {"@type": "/cosmos.gov.v1beta1.TextProposal", "title": "Text", "description": "A text proposal"}
+}`)
+	res := sys.Run(zaptest.NewLogger(t), "tx", "gov", "submit-proposal", both, "--generate-only", "--gas", "100000")
+	require.ErrorContains(t, res.Err, "either messages or a legacy content")
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/strangelove-ventures/lens/client/query"
+)
+
+const (
+	flagStartHeight = "start-height"
+	flagEndHeight   = "end-height"
+	flagStartDate   = "start-date"
+	flagEndDate     = "end-date"
+	flagStep        = "step"
+	flagWorkers     = "workers"
+	flagCSV         = "csv"
+)
+
+// historyCmd returns the command to query what an address held over a range of heights
+func historyCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [key-or-address]",
+		Args:  cobra.RangeArgs(0, 1),
+		Short: "query the balances, delegations and pending rewards of an address over a range of heights",
+		Long: strings.TrimSpace(`Query the balances, delegations and pending rewards of a key or address, or of the default key,
+at every --step blocks from the start to the end of a range. The range is given by heights, or
+by dates resolved to the height of the last block at or before them, and ends at the latest
+block by default. The node has to have the state of all the heights, like an archive node.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query history cosmos1... --start-height 15000000 --end-height 15100000 --step 10000
+$ %s query history --start-date 2023-01-01 --end-date 2023-02-01 --step 14400 --csv`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			// The heights and pagination of the queries are set by the history, only the
+			// route is left to the flags.
+			routeFlag, _ := cmd.Flags().GetString(flagRoute)
+			route, err := query.ParseRoute(routeFlag)
+			if err != nil {
+				return err
+			}
+			opts := query.DefaultOptions()
+			opts.Route = route
+			key := cl.Config.Key
+			if len(args) == 1 {
+				key = args[0]
+			}
+			address, err := addressFromKeyOrAddress(cl, key)
+			if err != nil {
+				return err
+			}
+
+			start, end, err := historyRangeFromFlags(cmd.Context(), cl, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			step, _ := cmd.Flags().GetInt64(flagStep)
+			heights, err := query.HistoryHeights(start, end, step)
+			if err != nil {
+				return err
+			}
+
+			workers, _ := cmd.Flags().GetInt(flagWorkers)
+			q := query.Query{Client: cl, Options: opts}
+			points, err := q.History(cmd.Context(), cl.MustEncodeAccAddr(address), heights, workers)
+			if err != nil {
+				return err
+			}
+			if asCSV, _ := cmd.Flags().GetBool(flagCSV); asCSV {
+				return writeHistoryCSV(cmd.OutOrStdout(), points)
+			}
+			return cl.PrintObject(points)
+		},
+	}
+	return historyFlags(a.Viper, cmd)
+}
+
+func historyFlags(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Int64(flagStartHeight, 0, "first height of the range")
+	cmd.Flags().Int64(flagEndHeight, 0, "last height of the range, the latest height if neither it nor --end-date are set")
+	cmd.Flags().String(flagStartDate, "", "start the range at the last block at or before this date (RFC3339 or YYYY-MM-DD in UTC)")
+	cmd.Flags().String(flagEndDate, "", "end the range at the last block at or before this date (RFC3339 or YYYY-MM-DD in UTC)")
+	cmd.Flags().Int64(flagStep, 0, "number of blocks between two heights of the range, only the start and end if 0")
+	cmd.Flags().Int(flagWorkers, query.DefaultHistoryWorkers, "number of heights to query at the same time")
+	cmd.Flags().Bool(flagCSV, false, "print the history as CSV rows of height, time, kind, validator, denom and amount")
+	for _, flag := range []string{flagStartHeight, flagEndHeight, flagStartDate, flagEndDate, flagStep, flagWorkers, flagCSV} {
+		if err := v.BindPFlag(flag, cmd.Flags().Lookup(flag)); err != nil {
+			panic(err)
+		}
+	}
+	cmd.MarkFlagsMutuallyExclusive(flagStartHeight, flagStartDate)
+	cmd.MarkFlagsMutuallyExclusive(flagEndHeight, flagEndDate)
+	return cmd
+}
+
+// historyRangeFromFlags returns the start and end heights of the range of the flags,
+// resolving dates to heights.
+func historyRangeFromFlags(ctx context.Context, cl *client.ChainClient, flags *pflag.FlagSet) (int64, int64, error) {
+	height := func(heightFlag, dateFlag string) (int64, error) {
+		if date, _ := flags.GetString(dateFlag); date != "" {
+			t, err := parseHistoryDate(date)
+			if err != nil {
+				return 0, fmt.Errorf("invalid --%s: %w", dateFlag, err)
+			}
+			return cl.HeightAtTime(ctx, t)
+		}
+		return flags.GetInt64(heightFlag)
+	}
+
+	if !flags.Changed(flagStartHeight) && !flags.Changed(flagStartDate) {
+		return 0, 0, fmt.Errorf("--%s or --%s is required", flagStartHeight, flagStartDate)
+	}
+	start, err := height(flagStartHeight, flagStartDate)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := height(flagEndHeight, flagEndDate)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end == 0 {
+		status, err := cl.RPCClient.Status(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+		end = status.SyncInfo.LatestBlockHeight
+	}
+	return start, end, nil
+}
+
+// parseHistoryDate parses an RFC3339 time, or a YYYY-MM-DD date at midnight UTC.
+func parseHistoryDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, errors.New("expected an RFC3339 time or a YYYY-MM-DD date")
+	}
+	return t, nil
+}
+
+// writeHistoryCSV writes a row per balance, delegation and pending reward of each point.
+func writeHistoryCSV(out io.Writer, points []query.HistoryPoint) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"height", "time", "kind", "validator", "denom", "amount"}); err != nil {
+		return err
+	}
+	for _, p := range points {
+		height, t := strconv.FormatInt(p.Height, 10), p.Time.UTC().Format(time.RFC3339)
+		for _, c := range p.Balances {
+			if err := w.Write([]string{height, t, "balance", "", c.Denom, c.Amount.String()}); err != nil {
+				return err
+			}
+		}
+		for _, d := range p.Delegations {
+			if err := w.Write([]string{height, t, "delegation", d.Delegation.ValidatorAddress, d.Balance.Denom, d.Balance.Amount.String()}); err != nil {
+				return err
+			}
+		}
+		for _, c := range p.Rewards {
+			if err := w.Write([]string{height, t, "reward", "", c.Denom, c.Amount.String()}); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,27 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestQueryHistory_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+
+	res := sys.Run(zaptest.NewLogger(t), "query", "history")
+	require.ErrorContains(t, res.Err, "--start-height or --start-date is required")
+
+	res = sys.Run(zaptest.NewLogger(t), "query", "history", "--start-date", "01/01/2023")
+	require.ErrorContains(t, res.Err, "invalid --start-date")
+
+	res = sys.Run(zaptest.NewLogger(t), "query", "history", "--start-height", "200", "--end-height", "100")
+	require.ErrorContains(t, res.Err, "not after the end height")
+
+	res = sys.Run(zaptest.NewLogger(t), "query", "history", "--start-height", "100", "--start-date", "2023-01-01")
+	require.ErrorContains(t, res.Err, "none of the others can be")
+}
@@ -0,0 +1,517 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/strangelove-ventures/lens/client/query"
+)
+
+const (
+	flagTimeoutHeightOffset = "timeout-height-offset"
+	flagTimeoutTimeOffset   = "timeout-time-offset"
+	flagPacketMemo          = "packet-memo"
+	flagSequences           = "sequences"
+)
+
+// ibcTransferCmd returns the command to transfer tokens to another chain over IBC
+func ibcTransferCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "transfer [channel] [receiver] [amount] [key]?",
+		Aliases: []string{"t"},
+		Args:    cobra.RangeArgs(3, 4),
+		Short:   "transfer tokens to an address on the counterparty chain of a transfer channel",
+		Long: strings.TrimSpace(`Transfer tokens over the transfer port of a channel, from the default key if none is given.
+The packet times out --timeout-height-offset blocks and --timeout-time-offset after the
+latest height and time of the counterparty chain known to this chain. Set an offset to
+0 to disable that timeout.
+
+If the counterparty chain is configured, the receiver must have its bech32 prefix.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx ibc transfer channel-141 osmo1... 1000000uatom mykey
+$ %s tx ibc transfer channel-141 osmo1... 1000000uatom --timeout-height-offset 0 --timeout-time-offset 1h`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			var key string
+			if len(args) == 4 {
+				key = args[3]
+			}
+			sender, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoinNormalized(args[2])
+			if err != nil {
+				return err
+			}
+
+			var transferOpts client.IBCTransferOptions
+			transferOpts.TimeoutHeightOffset, _ = cmd.Flags().GetUint64(flagTimeoutHeightOffset)
+			transferOpts.TimeoutTimeOffset, _ = cmd.Flags().GetDuration(flagTimeoutTimeOffset)
+			transferOpts.PacketMemo, _ = cmd.Flags().GetString(flagPacketMemo)
+			transferOpts.CounterpartyPrefixes = accountPrefixes(a)
+			msg, err := cl.NewIBCTransfer(cmd.Context(), sender, args[0], args[1], amount, transferOpts)
+			if err != nil {
+				return err
+			}
+
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+	cmd.Flags().Uint64(flagTimeoutHeightOffset, 1000, "number of counterparty blocks after which the transfer times out")
+	cmd.Flags().Duration(flagTimeoutTimeOffset, 10*time.Minute, "counterparty time after which the transfer times out")
+	cmd.Flags().String(flagPacketMemo, "", "memo of the transfer packet, read by the counterparty chain")
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// accountPrefixes returns the bech32 account prefixes of the configured chains by chain id.
+func accountPrefixes(a *appState) map[string]string {
+	prefixes := make(map[string]string, len(a.Config.Chains))
+	for _, chain := range a.Config.Chains {
+		prefixes[chain.ChainID] = chain.AccountPrefix
+	}
+	return prefixes
+}
+
+// configuredChain returns the name of the configured chain with chainID, or "" if there is none.
+func configuredChain(a *appState, chainID string) string {
+	for name, chain := range a.Config.Chains {
+		if chain.ChainID == chainID {
+			return name
+		}
+	}
+	return ""
+}
+
+// ========== Querier Functions ==========
+
+// ibcPacketStatusCmd returns the command to follow the packets sent by a tx
+func ibcPacketStatusCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "packet-status [txhash]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the status of the IBC packets sent by a tx on the default chain",
+		Long: strings.TrimSpace(`Query whether the IBC packets sent by a tx were received, acknowledged or timed out.
+The destination chain of each packet is the configured chain whose chain id matches the
+client of the packet's channel.
+
+The state of a packet is one of:
+  pending       neither received nor timed out yet
+  received      received, the acknowledgement is not relayed back yet
+  acknowledged  received and the acknowledgement relayed back
+  timed-out     not received before the timeout, refunded once "committed" is false
+
+Once a packet is received, "ack_written" tells whether the destination chain wrote its
+acknowledgement, and "ack_result" whether that acknowledgement is a success or an error.
+The result is read from the txs of the destination chain, it is "unknown" when its node
+doesn't index txs.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc packet-status 8B6A0D0E6C1D2E5F...`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			src := query.Query{Client: cl, Options: opts}
+
+			statuses, err := src.Ibc_TxPacketStatuses(args[0], func(packet channeltypes.Packet) (*query.Query, error) {
+				return counterpartyQuery(a, &src, packet.SourcePort, packet.SourceChannel)
+			})
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(statuses)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// counterpartyQuery returns a query against the configured counterparty chain of the channel on portId.
+func counterpartyQuery(a *appState, q *query.Query, portId, channelId string) (*query.Query, error) {
+	_, clientState, err := q.Ibc_CounterpartyClientState(portId, channelId)
+	if err != nil {
+		return nil, err
+	}
+	tmClientState, ok := clientState.(*ibctm.ClientState)
+	if !ok {
+		return nil, fmt.Errorf("the client of %s is not a tendermint client", channelId)
+	}
+	name := configuredChain(a, tmClientState.ChainId)
+	if name == "" {
+		return nil, fmt.Errorf("counterparty chain %s of %s is not configured", tmClientState.ChainId, channelId)
+	}
+	return &query.Query{Client: a.Config.GetClient(name), Options: query.DefaultOptions()}, nil
+}
+
+// parseSequences parses a comma separated list of packet sequences.
+func parseSequences(s string) ([]uint64, error) {
+	var sequences []uint64
+	for _, seq := range strings.Split(s, ",") {
+		seq = strings.TrimSpace(seq)
+		if seq == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(seq, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet sequence %q: %w", seq, err)
+		}
+		sequences = append(sequences, n)
+	}
+	return sequences, nil
+}
+
+// ibcClientsCmd returns the command to query the states of all light clients
+func ibcClientsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clients",
+		Args:  cobra.NoArgs,
+		Short: "query the states of all IBC light clients",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Ibc_ClientStates()
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// ibcClientStateCmd returns the command to query the state of a light client
+func ibcClientStateCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "client-state [client-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the state of an IBC light client",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc client-state 07-tendermint-259`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Ibc_ClientState(args[0])
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// ibcConsensusStatesCmd returns the command to query the consensus states of a light client
+func ibcConsensusStatesCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "consensus-states [client-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the consensus states stored by an IBC light client",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc consensus-states 07-tendermint-259 --reverse --limit 1`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Ibc_ConsensusStates(args[0])
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// ibcConnectionsCmd returns the command to query all connections
+func ibcConnectionsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connections",
+		Args:  cobra.NoArgs,
+		Short: "query all IBC connections",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Ibc_Connections()
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// ibcChannelsCmd returns the command to query all channels
+func ibcChannelsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "channels",
+		Args:  cobra.NoArgs,
+		Short: "query all IBC channels",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Ibc_Channels()
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// ibcConnectionChannelsCmd returns the command to query the channels of a connection
+func ibcConnectionChannelsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "channel-by-connection [connection-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the IBC channels built on a connection",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc channel-by-connection connection-257`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Ibc_ConnectionChannels(args[0])
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// ibcPacketCommitmentsCmd returns the command to query the packet commitments of a channel
+func ibcPacketCommitmentsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "packet-commitments [port] [channel]",
+		Args:  cobra.ExactArgs(2),
+		Short: "query the commitments of the packets sent over a channel and not acknowledged or timed out yet",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc packet-commitments transfer channel-141`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Ibc_PacketCommitments(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// ibcUnreceivedPacketsCmd returns the command to query the packets not received over a channel
+func ibcUnreceivedPacketsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unreceived-packets [port] [channel]",
+		Args:  cobra.ExactArgs(2),
+		Short: "query the packets sent by the counterparty chain that were not received over a channel",
+		Long: strings.TrimSpace(`Query which of the packets sent to a channel of the default chain were not received.
+The sequences to check are given with --sequences, or read from the packet commitments of
+the counterparty channel if the counterparty chain is configured.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc unreceived-packets transfer channel-0
+$ %s query ibc unreceived-packets transfer channel-0 --sequences 12,13`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			q := query.Query{Client: cl, Options: opts}
+
+			sequences, err := sequencesFromFlags(cmd, a, &q, args[0], args[1], func(cp *query.Query, port, channel string) ([]*channeltypes.PacketState, error) {
+				res, err := cp.Ibc_PacketCommitments(port, channel)
+				if err != nil {
+					return nil, err
+				}
+				return res.Commitments, nil
+			})
+			if err != nil {
+				return err
+			}
+			res, err := q.Ibc_UnreceivedPackets(args[0], args[1], sequences)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	cmd.Flags().String(flagSequences, "", "comma separated packet sequences to check instead of the counterparty packet commitments")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// ibcUnreceivedAcksCmd returns the command to query the acknowledgements not received over a channel
+func ibcUnreceivedAcksCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unreceived-acks [port] [channel]",
+		Args:  cobra.ExactArgs(2),
+		Short: "query the packets sent over a channel whose acknowledgement was not relayed back",
+		Long: strings.TrimSpace(`Query which of the packets sent over a channel of the default chain did not get their
+acknowledgement relayed back. The sequences to check are given with --sequences, or read
+from the packet acknowledgements of the counterparty channel if the counterparty chain
+is configured.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc unreceived-acks transfer channel-141
+$ %s query ibc unreceived-acks transfer channel-141 --sequences 12,13`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			q := query.Query{Client: cl, Options: opts}
+
+			sequences, err := sequencesFromFlags(cmd, a, &q, args[0], args[1], func(cp *query.Query, port, channel string) ([]*channeltypes.PacketState, error) {
+				res, err := cp.Ibc_PacketAcknowledgements(port, channel)
+				if err != nil {
+					return nil, err
+				}
+				return res.Acknowledgements, nil
+			})
+			if err != nil {
+				return err
+			}
+			res, err := q.Ibc_UnreceivedAcks(args[0], args[1], sequences)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	cmd.Flags().String(flagSequences, "", "comma separated packet sequences to check instead of the counterparty packet acknowledgements")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// sequencesFromFlags returns the packet sequences of --sequences, or the sequences of the packet
+// states that counterparty returns for the counterparty end of the channel.
+func sequencesFromFlags(cmd *cobra.Command, a *appState, q *query.Query, portId, channelId string,
+	counterparty func(cp *query.Query, port, channel string) ([]*channeltypes.PacketState, error)) ([]uint64, error) {
+	if cmd.Flags().Changed(flagSequences) {
+		s, _ := cmd.Flags().GetString(flagSequences)
+		return parseSequences(s)
+	}
+
+	channel, err := q.Ibc_Channel(channelId, portId)
+	if err != nil {
+		return nil, err
+	}
+	cp, err := counterpartyQuery(a, q, portId, channelId)
+	if err != nil {
+		return nil, fmt.Errorf("%w, pass the sequences with --%s", err, flagSequences)
+	}
+	states, err := counterparty(cp, channel.Channel.Counterparty.PortId, channel.Channel.Counterparty.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	sequences := make([]uint64, 0, len(states))
+	for _, state := range states {
+		sequences = append(sequences, state.Sequence)
+	}
+	return sequences, nil
+}
+
+// ibcDenomTraceCmd returns the command to query the denom trace of an IBC denom
+func ibcDenomTraceCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "denom-trace [hash|ibc/hash]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the path and base denom of an IBC denom",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc denom-trace ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Transfer_DenomTrace(args[0])
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// ibcEscrowAddressCmd returns the command to query the escrow address of a channel
+func ibcEscrowAddressCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "escrow-address [port] [channel]",
+		Args:  cobra.ExactArgs(2),
+		Short: "query the address escrowing the tokens transferred over a channel",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query ibc escrow-address transfer channel-141`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Transfer_EscrowAddress(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
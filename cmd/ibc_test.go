@@ -0,0 +1,19 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestQueryIbcUnreceivedPackets_InvalidSequences(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	res := sys.Run(zaptest.NewLogger(t), "query", "ibc", "unreceived-packets", "transfer", "channel-0", "--sequences", "1,abc")
+	require.ErrorContains(t, res.Err, `invalid packet sequence "abc"`)
+
+	res = sys.Run(zaptest.NewLogger(t), "query", "ibc", "unreceived-acks", "transfer")
+	require.ErrorContains(t, res.Err, "accepts 2 arg(s)")
+}
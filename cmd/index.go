@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/strangelove-ventures/lens/client/indexer"
+	"go.uber.org/zap"
+)
+
+const (
+	flagDB           = "db"
+	flagFollow       = "follow"
+	flagPollInterval = "poll-interval"
+)
+
+// indexCmd returns the command to index the blocks of the default chain into SQLite
+func indexCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "index",
+		Args:  cobra.NoArgs,
+		Short: "index the blocks, txs, messages and events of a chain into a SQLite database",
+		Long: strings.TrimSpace(`Walk the blocks of the default chain and write their txs, decoded messages, events and
+attributes into a SQLite database. Blocks are fetched by several workers at the same time and
+committed in order of height, so an interrupted index resumes after its last block. With
+--follow the index keeps up with the new blocks of the chain until it is interrupted.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s index --start-height 15000000 --end-height 15001000
+$ %s index --follow --db ./cosmoshub.db`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			start, _ := cmd.Flags().GetInt64(flagStartHeight)
+			end, _ := cmd.Flags().GetInt64(flagEndHeight)
+			workers, _ := cmd.Flags().GetInt(flagWorkers)
+			follow, _ := cmd.Flags().GetBool(flagFollow)
+			interval, _ := cmd.Flags().GetDuration(flagPollInterval)
+			if end > 0 && start > end {
+				return fmt.Errorf("--%s must not be after --%s", flagStartHeight, flagEndHeight)
+			}
+
+			dbPath, _ := cmd.Flags().GetString(flagDB)
+			if dbPath == "" {
+				dbPath = filepath.Join(a.HomePath, "index", cl.Config.ChainID+".db")
+			}
+			if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+				return err
+			}
+			store, err := indexer.OpenStore(dbPath)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			// Blocks are committed whole, stopping between two of them leaves a consistent index
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			a.Log.Info("Indexing chain", zap.String("chain_id", cl.Config.ChainID), zap.String("db", dbPath))
+			ix := indexer.New(a.Log, cl, store, indexer.Options{
+				StartHeight:  start,
+				EndHeight:    end,
+				Workers:      workers,
+				Follow:       follow,
+				PollInterval: interval,
+			})
+			err = ix.Run(ctx)
+			if ctx.Err() != nil && cmd.Context().Err() == nil {
+				a.Log.Info("Index interrupted")
+				return nil
+			}
+			return err
+		},
+	}
+	return indexFlags(a.Viper, cmd)
+}
+
+func indexFlags(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(flagDB, "", "path of the SQLite database, index/<chain-id>.db in the home directory by default")
+	cmd.Flags().Int64(flagStartHeight, 0, "first height to index when the database has no later block, the earliest height of the node by default")
+	cmd.Flags().Int64(flagEndHeight, 0, "last height to index, the latest height by default")
+	cmd.Flags().Int(flagWorkers, indexer.DefaultWorkers, "number of blocks to fetch at the same time")
+	cmd.Flags().Bool(flagFollow, false, "keep indexing the new blocks of the chain")
+	cmd.Flags().Duration(flagPollInterval, indexer.DefaultPollInterval, "how often to look for new blocks with --follow")
+	for _, flag := range []string{flagDB, flagStartHeight, flagEndHeight, flagWorkers, flagFollow, flagPollInterval} {
+		if err := v.BindPFlag(flag, cmd.Flags().Lookup(flag)); err != nil {
+			panic(err)
+		}
+	}
+	return cmd
+}
@@ -0,0 +1,17 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestIndex_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+
+	res := sys.Run(zaptest.NewLogger(t), "index", "--start-height", "200", "--end-height", "100")
+	require.ErrorContains(t, res.Err, "--start-height must not be after --end-height")
+}
@@ -55,7 +55,8 @@ func keysAddCmd(a *appState) *cobra.Command {
 		Example: strings.TrimSpace(fmt.Sprintf(`
 $ %s keys add
 $ %s keys add test_key
-$ %s k a osmo_key --chain osmosis`, appName, appName, appName)),
+$ %s k a osmo_key --chain osmosis
+$ %s keys add treasury --multisig alice,bob,carol --multisig-threshold 2`, appName, appName, appName, appName)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cl := a.Config.GetDefaultClient()
 			var keyName string
@@ -68,6 +69,17 @@ $ %s k a osmo_key --chain osmosis`, appName, appName, appName)),
 				return errKeyExists(keyName)
 			}
 
+			if members, _ := cmd.Flags().GetStringSlice(flagMultisig); len(members) > 0 {
+				threshold, _ := cmd.Flags().GetInt(flagMultisigThresh)
+				noSort, _ := cmd.Flags().GetBool(flagNoSort)
+				address, err := cl.AddMultisigKey(keyName, members, threshold, noSort)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), address)
+				return nil
+			}
+
 			coinType, err := cmd.Flags().GetUint32(flagCoinType)
 			if err != nil {
 				return err
@@ -90,6 +102,9 @@ $ %s k a osmo_key --chain osmosis`, appName, appName, appName)),
 		},
 	}
 	cmd.Flags().Uint32(flagCoinType, defaultCoinType, "coin type number for HD derivation")
+	cmd.Flags().StringSlice(flagMultisig, nil, "comma separated names of keys to build a multisig key from, instead of creating a new key")
+	cmd.Flags().Int(flagMultisigThresh, 1, "number of member signatures required by the multisig key")
+	cmd.Flags().Bool(flagNoSort, false, "keep the multisig members in the given order instead of sorting them by address")
 	return cmd
 }
 
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client"
+)
+
+// txSignCmd returns the command to sign a tx generated with --generate-only.
+func txSignCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign [tx.json] [key]?",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "sign a transaction generated with --generate-only",
+		Long: strings.TrimSpace(`Sign a transaction read from a JSON file, with the default key if none is given.
+With --offline the chain is not contacted, so the account number and sequence of the
+signing account have to be given. The signed transaction can then be broadcast from
+another machine with "lens tx broadcast".
+
+With --multisig the key signs as a member of the given multisig key, and only its
+signature is printed. Combine the signatures of the members with "lens tx multisign".`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx bank send mykey cosmos1... 100uatom --generate-only --gas 100000 > unsigned.json
+$ %s tx sign unsigned.json mykey --offline --account-number 12 --sequence 3 --output-document signed.json
+$ %s tx broadcast signed.json
+$ %s tx sign unsigned.json alice --multisig treasury --output-document alice.json`, appName, appName, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			key := cl.Config.Key
+			if len(args) == 2 {
+				key = args[1]
+			}
+
+			tx, err := readTxFile(cl, args[0])
+			if err != nil {
+				return err
+			}
+
+			// The account number and sequence are those of the account the tx is signed for.
+			account := key
+			multisigKey, _ := cmd.Flags().GetString(flagMultisig)
+			if multisigKey != "" {
+				account = multisigKey
+			}
+			num, seq, err := signerAccount(cmd, cl, account)
+			if err != nil {
+				return err
+			}
+
+			var out []byte
+			if multisigKey != "" {
+				sig, err := cl.SignMultisigTx(tx, key, multisigKey, num, seq)
+				if err != nil {
+					return err
+				}
+				if out, err = cl.SignatureJSON(sig); err != nil {
+					return err
+				}
+			} else {
+				overwrite, _ := cmd.Flags().GetBool(flagOverwrite)
+				txb, err := cl.SignTx(tx, key, num, seq, overwrite)
+				if err != nil {
+					return err
+				}
+				if out, err = cl.TxJSON(txb.GetTx()); err != nil {
+					return err
+				}
+			}
+			return writeOutputDocument(cmd, out)
+		},
+	}
+	cmd.Flags().String(flagMultisig, "", "name of the multisig key to produce a partial signature for")
+	return signFlags(a.Viper, cmd)
+}
+
+// txMultisignCmd returns the command to combine partial signatures of a multisig.
+func txMultisignCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisign [tx.json] [multisig-key] [signature.json]...",
+		Args:  cobra.MinimumNArgs(3),
+		Short: "combine the signatures of multisig members into a signed transaction",
+		Long: strings.TrimSpace(`Combine signatures created with "lens tx sign --multisig" into a transaction
+signed by the multisig key, which can be broadcast with "lens tx broadcast".`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx multisign unsigned.json treasury alice.json bob.json --output-document signed.json`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+
+			tx, err := readTxFile(cl, args[0])
+			if err != nil {
+				return err
+			}
+
+			var sigs []signing.SignatureV2
+			for _, path := range args[2:] {
+				bz, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				fileSigs, err := cl.ParseSignatureJSON(bz)
+				if err != nil {
+					return fmt.Errorf("reading signatures from %s: %w", path, err)
+				}
+				sigs = append(sigs, fileSigs...)
+			}
+
+			num, seq, err := signerAccount(cmd, cl, args[1])
+			if err != nil {
+				return err
+			}
+			txb, err := cl.CombineMultisigTx(tx, args[1], num, seq, sigs)
+			if err != nil {
+				return err
+			}
+			out, err := cl.TxJSON(txb.GetTx())
+			if err != nil {
+				return err
+			}
+			return writeOutputDocument(cmd, out)
+		},
+	}
+	return signFlags(a.Viper, cmd)
+}
+
+// txBroadcastCmd returns the command to broadcast a tx signed with `lens tx sign`.
+func txBroadcastCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast [signed-tx.json]",
+		Args:  cobra.ExactArgs(1),
+		Short: "broadcast a transaction signed with the sign command",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+
+			tx, err := readTxFile(cl, args[0])
+			if err != nil {
+				return err
+			}
+
+			return cl.HandleAndPrintMsgSend(cl.BroadcastSignedTx(cmd.Context(), tx))
+		},
+	}
+	return cmd
+}
+
+// decodedTx is a tx decoded by the decode command, the tx is in the format of the sign command.
+type decodedTx struct {
+	Hash    string          `json:"hash"`
+	Signers []string        `json:"signers"`
+	Tx      json.RawMessage `json:"tx"`
+}
+
+// txDecodeCmd returns the command to decode the bytes of a tx.
+func txDecodeCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode [hex-or-base64-tx]",
+		Args:  cobra.ExactArgs(1),
+		Short: "decode the bytes of a transaction, like those of a block or the mempool, and print it as JSON",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx decode CpIBCo8BChwvY29zbW9zLmJhbmsudjFiZXRhMS5Nc2dTZW5k...
+$ %s tx decode 0x0a920a...`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+
+			bz, err := client.ParseTxBytes(args[0])
+			if err != nil {
+				return err
+			}
+			tx, err := cl.DecodeTx(bz)
+			if err != nil {
+				return fmt.Errorf("failed to decode tx: %w", err)
+			}
+			signers, err := cl.TxSigners(tx)
+			if err != nil {
+				return err
+			}
+			txJSON, err := cl.TxJSON(tx)
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd.OutOrStdout(), decodedTx{
+				Hash:    fmt.Sprintf("%X", tmtypes.Tx(bz).Hash()),
+				Signers: signers,
+				Tx:      txJSON,
+			})
+		},
+	}
+	return cmd
+}
+
+// txEncodeCmd returns the command to encode a tx from JSON to the bytes that are broadcast.
+func txEncodeCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encode [tx.json]",
+		Args:  cobra.ExactArgs(1),
+		Short: "encode a transaction from JSON, as printed by the sign or decode commands, and print its bytes as base64",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			// The output of the decode command holds the tx with its hash and signers
+			var decoded decodedTx
+			if err := json.Unmarshal(bz, &decoded); err == nil && len(decoded.Tx) > 0 {
+				bz = decoded.Tx
+			}
+			tx, err := cl.ParseTxJSON(bz)
+			if err != nil {
+				return err
+			}
+			txBytes, err := cl.EncodeTx(tx)
+			if err != nil {
+				return err
+			}
+
+			if asHex, _ := cmd.Flags().GetBool(flagHex); asHex {
+				fmt.Fprintln(cmd.OutOrStdout(), hex.EncodeToString(txBytes))
+				return nil
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), base64.StdEncoding.EncodeToString(txBytes))
+			return nil
+		},
+	}
+	cmd.Flags().Bool(flagHex, false, "print the bytes as hex instead of base64")
+	if err := a.Viper.BindPFlag(flagHex, cmd.Flags().Lookup(flagHex)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+// signerAccount returns the account number and sequence to sign for keyOrAddress with,
+// as given by the flags. Unless --offline is set, those not given are queried from chain.
+func signerAccount(cmd *cobra.Command, cl *client.ChainClient, keyOrAddress string) (num, seq uint64, err error) {
+	num, _ = cmd.Flags().GetUint64(flagAccountNumber)
+	seq, _ = cmd.Flags().GetUint64(flagSequence)
+	numSet, seqSet := cmd.Flags().Changed(flagAccountNumber), cmd.Flags().Changed(flagSequence)
+	if numSet && seqSet {
+		return num, seq, nil
+	}
+	if offline, _ := cmd.Flags().GetBool(flagOffline); offline {
+		return 0, 0, fmt.Errorf("--%s and --%s are required with --%s", flagAccountNumber, flagSequence, flagOffline)
+	}
+
+	addr, err := addressFromKeyOrAddress(cl, keyOrAddress)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	chainNum, chainSeq, err := cl.GetAccountNumberSequence(sdkclient.Context{}, addr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !numSet {
+		num = chainNum
+	}
+	if !seqSet {
+		seq = chainSeq
+	}
+	return num, seq, nil
+}
+
+// readTxFile reads a tx encoded as JSON from path.
+func readTxFile(cl *client.ChainClient, path string) (sdk.Tx, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return cl.ParseTxJSON(bz)
+}
+
+// writeOutputDocument writes out to the file given by --output-document, or stdout.
+func writeOutputDocument(cmd *cobra.Command, out []byte) error {
+	if path, _ := cmd.Flags().GetString(flagOutputDocument); path != "" {
+		return os.WriteFile(path, append(out, '\n'), 0600)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(out))
+	return nil
+}
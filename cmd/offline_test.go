@@ -0,0 +1,142 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTxGenerateOnly_SignOffline(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+
+	// Neither generating with a fixed gas nor signing offline contacts the chain.
+	res := sys.MustRun(t, "tx", "bank", "send", "default", "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "100uatom",
+		"--generate-only", "--gas", "100000", "--memo", "cold")
+	var unsigned struct {
+		Body struct {
+			Memo string `json:"memo"`
+		} `json:"body"`
+		AuthInfo struct {
+			Fee struct {
+				GasLimit string `json:"gas_limit"`
+			} `json:"fee"`
+		} `json:"auth_info"`
+		Signatures []string `json:"signatures"`
+	}
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &unsigned))
+	require.Equal(t, "cold", unsigned.Body.Memo)
+	require.Equal(t, "100000", unsigned.AuthInfo.Fee.GasLimit)
+	require.Empty(t, unsigned.Signatures)
+
+	unsignedPath := filepath.Join(t.TempDir(), "unsigned.json")
+	require.NoError(t, os.WriteFile(unsignedPath, res.Stdout.Bytes(), 0600))
+
+	res = sys.Run(zaptest.NewLogger(t), "tx", "sign", unsignedPath, "--offline")
+	require.ErrorContains(t, res.Err, "--account-number and --sequence are required")
+
+	signedPath := filepath.Join(t.TempDir(), "signed.json")
+	sys.MustRun(t, "tx", "sign", unsignedPath, "--offline", "--account-number", "12", "--sequence", "3", "--output-document", signedPath)
+
+	bz, err := os.ReadFile(signedPath)
+	require.NoError(t, err)
+	var signed struct {
+		AuthInfo struct {
+			SignerInfos []struct {
+				Sequence string `json:"sequence"`
+			} `json:"signer_infos"`
+		} `json:"auth_info"`
+		Signatures []string `json:"signatures"`
+	}
+	require.NoError(t, json.Unmarshal(bz, &signed))
+	require.Len(t, signed.Signatures, 1)
+	require.Len(t, signed.AuthInfo.SignerInfos, 1)
+	require.Equal(t, "3", signed.AuthInfo.SignerInfos[0].Sequence)
+}
+
+func TestTxMultisign(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		sys.MustRun(t, "keys", "add", name)
+	}
+	res := sys.MustRun(t, "keys", "add", "treasury", "--multisig", "alice,bob,carol", "--multisig-threshold", "2")
+	require.Contains(t, res.Stdout.String(), "cosmos1")
+
+	dir := t.TempDir()
+	res = sys.MustRun(t, "tx", "bank", "send", "treasury", "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "100uatom",
+		"--generate-only", "--gas", "100000")
+	unsignedPath := filepath.Join(dir, "unsigned.json")
+	require.NoError(t, os.WriteFile(unsignedPath, res.Stdout.Bytes(), 0600))
+
+	account := []string{"--offline", "--account-number", "12", "--sequence", "3"}
+	var sigPaths []string
+	for _, name := range []string{"alice", "bob"} {
+		sigPath := filepath.Join(dir, name+".json")
+		sys.MustRun(t, append([]string{"tx", "sign", unsignedPath, name, "--multisig", "treasury", "--output-document", sigPath}, account...)...)
+		sigPaths = append(sigPaths, sigPath)
+	}
+
+	res = sys.Run(zaptest.NewLogger(t), append([]string{"tx", "multisign", unsignedPath, "treasury", sigPaths[0]}, account...)...)
+	require.ErrorContains(t, res.Err, "needs 2 signatures")
+
+	res = sys.MustRun(t, append(append([]string{"tx", "multisign", unsignedPath, "treasury"}, sigPaths...), account...)...)
+	var signed struct {
+		Signatures []string `json:"signatures"`
+	}
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &signed))
+	require.Len(t, signed.Signatures, 1)
+}
+
+func TestTxDecodeEncode(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+	res := sys.MustRun(t, "keys", "show")
+	address := strings.TrimSpace(res.Stdout.String())
+
+	res = sys.MustRun(t, "tx", "bank", "send", "default", "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "100uatom",
+		"--generate-only", "--gas", "100000", "--memo", "raw")
+	txPath := filepath.Join(t.TempDir(), "tx.json")
+	require.NoError(t, os.WriteFile(txPath, res.Stdout.Bytes(), 0600))
+
+	res = sys.MustRun(t, "tx", "encode", txPath)
+	encoded := strings.TrimSpace(res.Stdout.String())
+	res = sys.MustRun(t, "tx", "encode", txPath, "--hex")
+	encodedHex := strings.TrimSpace(res.Stdout.String())
+
+	for _, bz := range []string{encoded, encodedHex} {
+		res = sys.MustRun(t, "tx", "decode", bz)
+		var decoded struct {
+			Hash    string   `json:"hash"`
+			Signers []string `json:"signers"`
+			Tx      struct {
+				Body struct {
+					Memo string `json:"memo"`
+				} `json:"body"`
+			} `json:"tx"`
+		}
+		require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &decoded))
+		require.Len(t, decoded.Hash, 64)
+		require.Equal(t, []string{address}, decoded.Signers)
+		require.Equal(t, "raw", decoded.Tx.Body.Memo)
+	}
+
+	// The output of decode encodes back to the same bytes
+	decodedPath := filepath.Join(t.TempDir(), "decoded.json")
+	require.NoError(t, os.WriteFile(decodedPath, res.Stdout.Bytes(), 0600))
+	res = sys.MustRun(t, "tx", "encode", decodedPath)
+	require.Equal(t, encoded, strings.TrimSpace(res.Stdout.String()))
+
+	res = sys.Run(zaptest.NewLogger(t), "tx", "decode", "not a tx!")
+	require.ErrorContains(t, res.Err, "hex or base64")
+}
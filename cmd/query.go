@@ -17,19 +17,16 @@ func queryCmd(a *appState) *cobra.Command {
 		authzQueryCmd(a),
 		bankQueryCmd(a),
 		distributionQueryCmd(a),
+		feegrantQueryCmd(a),
+		govQueryCmd(a),
+		historyCmd(a),
+		ibcQueryCmd(a),
+		slashingQueryCmd(a),
 		stakingQueryCmd(a),
+		txsQueryCmd(a),
 	)
 
-	if false {
-		// TODO: enable these when commands are available
-		cmd.AddCommand(
-			feegrantQueryCmd(),
-			govQueryCmd(),
-			slashingQueryCmd(),
-		)
-	}
-
-	return cmd
+	return routeFlag(a.Viper, cmd)
 }
 
 // authQueryCmd returns the transaction commands for this module
@@ -74,6 +71,7 @@ func bankQueryCmd(a *appState) *cobra.Command {
 
 	cmd.AddCommand(
 		bankBalanceCmd(a),
+		bankVerifiedBalanceCmd(a),
 		bankTotalSupplyCmd(a),
 		bankDenomsMetadataCmd(a),
 	)
@@ -103,7 +101,7 @@ func distributionQueryCmd(a *appState) *cobra.Command {
 }
 
 // feegrantQueryCmd returns the fee grant query commands for this module
-func feegrantQueryCmd() *cobra.Command {
+func feegrantQueryCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "feegrant",
 		Aliases: []string{"feegrant"},
@@ -111,15 +109,16 @@ func feegrantQueryCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
-	// feegrantGrantsCmd(),
-	// feegrantFeeGrantsCmd(),
+		feegrantGrantQueryCmd(a),
+		feegrantGrantsByGranteeCmd(a),
+		feegrantGrantsByGranterCmd(a),
 	)
 
 	return cmd
 }
 
 // govQueryCmd returns the gov query commands for this module
-func govQueryCmd() *cobra.Command {
+func govQueryCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "governance",
 		Aliases: []string{"gov", "g"},
@@ -127,23 +126,46 @@ func govQueryCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
-	// govProposalCmd(),
-	// govProposalsCmd(),
-	// govVoteCmd(),
-	// govVotesCmd(),
-	// govParamCmd(),
-	// govParamsCmd(),
-	// govProposerCmd(),
-	// govDepositCmd(),
-	// govDepositsCmd(),
-	// govTallyCmd(),
+		govProposalCmd(a),
+		govProposalsCmd(a),
+		govVoteQueryCmd(a),
+		govVotesCmd(a),
+		govParamsCmd(a),
+		govDepositQueryCmd(a),
+		govDepositsCmd(a),
+		govTallyCmd(a),
+	)
+
+	return cmd
+}
+
+// ibcQueryCmd returns the ibc query commands
+func ibcQueryCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ibc",
+		Short: "Querying commands for ibc",
+	}
+
+	cmd.AddCommand(
+		ibcClientsCmd(a),
+		ibcClientStateCmd(a),
+		ibcConsensusStatesCmd(a),
+		ibcConnectionsCmd(a),
+		ibcChannelsCmd(a),
+		ibcConnectionChannelsCmd(a),
+		ibcPacketCommitmentsCmd(a),
+		ibcUnreceivedPacketsCmd(a),
+		ibcUnreceivedAcksCmd(a),
+		ibcPacketStatusCmd(a),
+		ibcDenomTraceCmd(a),
+		ibcEscrowAddressCmd(a),
 	)
 
 	return cmd
 }
 
 // slashingQueryCmd returns the slashing query commands for this module
-func slashingQueryCmd() *cobra.Command {
+func slashingQueryCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "slashing",
 		Aliases: []string{"sl", "slash"},
@@ -151,9 +173,9 @@ func slashingQueryCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
-	// slashingSigningInfoCmd(),
-	// slashingParamsCmd(),
-	// slashingSigningInfosCmd(),
+		slashingSigningInfoCmd(a),
+		slashingParamsCmd(a),
+		slashingSigningInfosCmd(a),
 	)
 
 	return cmd
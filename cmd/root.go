@@ -75,9 +75,27 @@ func NewRootCmd(log *zap.Logger, atom zap.AtomicLevel, o map[string]ClientOverri
 			return err
 		}
 
+		// --broadcast-mode is only registered on the tx command tree
+		if f := cmd.Flags().Lookup(flagBroadcastMode); f != nil && f.Changed {
+			for chain := range a.Config.Chains {
+				a.Config.Chains[chain].BroadcastMode = f.Value.String()
+				if err := a.Config.Chains[chain].Validate(); err != nil {
+					return err
+				}
+			}
+		}
+
 		return nil
 	}
 
+	// Release the connections the chain clients made during the command
+	rootCmd.PersistentPostRunE = func(cmd *cobra.Command, _ []string) error {
+		if a.Config == nil {
+			return nil
+		}
+		return a.Config.closeClients()
+	}
+
 	// --home flag
 	rootCmd.PersistentFlags().StringVar(&a.HomePath, flags.FlagHome, defaultHome, "set home directory")
 	if err := a.Viper.BindPFlag(flags.FlagHome, rootCmd.PersistentFlags().Lookup(flags.FlagHome)); err != nil {
@@ -110,6 +128,7 @@ func NewRootCmd(log *zap.Logger, atom zap.AtomicLevel, o map[string]ClientOverri
 		versionCmd(),
 		airdropCmd(a),
 		dynamicCmd(a),
+		indexCmd(a),
 	)
 
 	return rootCmd
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/strangelove-ventures/lens/client/query"
+)
+
+// slashingUnjailCmd returns the command to unjail a validator
+func slashingUnjailCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unjail [key]?",
+		Aliases: []string{"u"},
+		Args:    cobra.RangeArgs(0, 1),
+		Short:   "unjail the validator operated by a key, the default key if none is given",
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tx slashing unjail operator`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			var key string
+			if len(args) == 1 {
+				key = args[0]
+			}
+			operator, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			msg := &slashingtypes.MsgUnjail{
+				ValidatorAddr: cl.MustEncodeValAddr(sdk.ValAddress(operator)),
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// ========== Querier Functions ==========
+
+// slashingSigningInfoCmd returns the command to query the signing info of a validator
+func slashingSigningInfoCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signing-info [valcons|valoper|pubkey]",
+		Args:  cobra.ExactArgs(1),
+		Short: "query the signing info of a validator",
+		Long: strings.TrimSpace(`Query the signing info of a validator by its consensus address, its operator
+address, or its consensus public key as printed by "<appd> tendermint show-validator".`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query slashing signing-info cosmosvalcons1...
+$ %s query slashing signing-info cosmosvaloper1...
+$ %s query slashing signing-info '{"@type":"/cosmos.crypto.ed25519.PubKey","key":"..."}'`, appName, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			consAddr, err := consAddressFromArg(&query, cl, args[0])
+			if err != nil {
+				return err
+			}
+			res, err := query.Slashing_SigningInfo(consAddr)
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res.ValSigningInfo)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// consAddressFromArg returns the bech32 consensus address of the validator given by its
+// consensus address, operator address or consensus public key JSON. The consensus key
+// of an operator address is looked up with the staking module.
+func consAddressFromArg(q *query.Query, cl *client.ChainClient, arg string) (string, error) {
+	if strings.HasPrefix(strings.TrimSpace(arg), "{") {
+		var pk cryptotypes.PubKey
+		if err := cl.Codec.Marshaler.UnmarshalInterfaceJSON([]byte(arg), &pk); err != nil {
+			return "", fmt.Errorf("parsing consensus public key: %w", err)
+		}
+		return cl.EncodeBech32ConsAddr(sdk.AccAddress(pk.Address()))
+	}
+
+	if valAddr, err := cl.DecodeBech32ValAddr(arg); err == nil {
+		res, err := q.Staking_Validator(cl.MustEncodeValAddr(valAddr))
+		if err != nil {
+			return "", err
+		}
+		consAddr, err := res.Validator.GetConsAddr()
+		if err != nil {
+			return "", err
+		}
+		return cl.EncodeBech32ConsAddr(sdk.AccAddress(consAddr))
+	}
+
+	consAddr, err := cl.DecodeBech32ConsAddr(arg)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a consensus address, operator address or consensus public key", arg)
+	}
+	return cl.EncodeBech32ConsAddr(consAddr)
+}
+
+// slashingSigningInfosCmd returns the command to query the signing infos of all validators
+func slashingSigningInfosCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signing-infos",
+		Args:  cobra.NoArgs,
+		Short: "query the signing infos of all validators",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Slashing_SigningInfos()
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return paginationFlags(cmd, a.Viper)
+}
+
+// slashingParamsCmd returns the command to query the slashing params
+func slashingParamsCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Args:  cobra.NoArgs,
+		Short: "query the slashing params",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			opts, err := queryOptionsFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			query := query.Query{Client: cl, Options: opts}
+			res, err := query.Slashing_Params()
+			if err != nil {
+				return err
+			}
+			return cl.PrintObject(res.Params)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
@@ -0,0 +1,26 @@
+package cmd_test
+
+import (
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxSlashingUnjail_GenerateOnly(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+	res := sys.MustRun(t, "keys", "show")
+	addr, err := sdk.GetFromBech32(strings.TrimSpace(res.Stdout.String()), "cosmos")
+	require.NoError(t, err)
+	valoper, err := sdk.Bech32ifyAddressBytes("cosmosvaloper", addr)
+	require.NoError(t, err)
+
+	msgs := generateOnlyMsgs(t, sys, "tx", "slashing", "unjail")
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.slashing.v1beta1.MsgUnjail", msgs[0]["@type"])
+	require.Equal(t, valoper, msgs[0]["validator_addr"])
+}
@@ -1,15 +1,32 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client"
 	"github.com/strangelove-ventures/lens/client/query"
 )
 
+const (
+	flagMoniker           = "moniker"
+	flagIdentity          = "identity"
+	flagWebsite           = "website"
+	flagSecurityContact   = "security-contact"
+	flagDetails           = "details"
+	flagCommissionRate    = "commission-rate"
+	flagMinSelfDelegation = "min-self-delegation"
+)
+
 func stakingDelegateCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delegate [validator-addr] [amount] ",
@@ -68,11 +85,11 @@ $ lens tx staking delegate cosmosvaloper1sjllsnramtg3ewxqwwrwjxfgc4n4ef9u2lcnj0
 				ValidatorAddress: cl.MustEncodeValAddr(valAddr),
 				Amount:           amount,
 			}
-			return cl.HandleAndPrintMsgSend(cl.SendMsg(cmd.Context(), msg, memo))
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
 
 		},
 	}
-	flags.AddTxFlagsToCmd(cmd)
+	AddTxFlagsToCmd(cmd)
 	memoFlag(a.Viper, cmd)
 	return cmd
 }
@@ -121,11 +138,340 @@ $ lens tx staking redelegate cosmosvaloper1sjllsnramtg3ewxqwwrwjxfgc4n4ef9u2lcnj
 				Amount:              amount,
 			}
 
-			return cl.HandleAndPrintMsgSend(cl.SendMsg(cmd.Context(), msg, memo))
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+
+	AddTxFlagsToCmd(cmd)
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+func stakingUnbondCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbond [validator-addr] [amount]",
+		Short: "unbond tokens from a validator",
+		Args:  cobra.ExactArgs(2),
+		Long: strings.TrimSpace(
+			`Unbond an amount of bonded tokens from a validator. They are liquid again
+once the unbonding period is over.
+Example:
+$ lens tx staking unbond cosmosvaloper1sjllsnramtg3ewxqwwrwjxfgc4n4ef9u2lcnj0 100stake --from mykey
+`,
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			key, _ := cmd.Flags().GetString(FlagFrom)
+			delAddr, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := cl.DecodeBech32ValAddr(args[0])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoinNormalized(args[1])
+			if err != nil {
+				return err
+			}
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			msg := &types.MsgUndelegate{
+				DelegatorAddress: cl.MustEncodeAccAddr(delAddr),
+				ValidatorAddress: cl.MustEncodeValAddr(valAddr),
+				Amount:           amount,
+			}
+
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+
+	AddTxFlagsToCmd(cmd)
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+func stakingCancelUnbondCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-unbond [validator-addr] [amount] [creation-height]",
+		Short: "cancel an unbonding delegation and delegate back to the validator",
+		Args:  cobra.ExactArgs(3),
+		Long: strings.TrimSpace(
+			`Cancel (part of) an unbonding delegation, delegating its tokens back to the validator.
+The unbonding delegation entry is given by its creation height, as shown by
+"lens query staking unbonding-delegation".
+Example:
+$ lens tx staking cancel-unbond cosmosvaloper1sjllsnramtg3ewxqwwrwjxfgc4n4ef9u2lcnj0 100stake 123456 --from mykey
+`,
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			key, _ := cmd.Flags().GetString(FlagFrom)
+			delAddr, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := cl.DecodeBech32ValAddr(args[0])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoinNormalized(args[1])
+			if err != nil {
+				return err
+			}
+
+			height, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil || height <= 0 {
+				return fmt.Errorf("creation height %s is not a positive integer", args[2])
+			}
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			msg := &types.MsgCancelUnbondingDelegation{
+				DelegatorAddress: cl.MustEncodeAccAddr(delAddr),
+				ValidatorAddress: cl.MustEncodeValAddr(valAddr),
+				Amount:           amount,
+				CreationHeight:   height,
+			}
+
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
 		},
 	}
 
-	flags.AddTxFlagsToCmd(cmd)
+	AddTxFlagsToCmd(cmd)
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// validatorSpec is the JSON file read by create-validator.
+type validatorSpec struct {
+	PubKey                  json.RawMessage `json:"pubkey"`
+	Amount                  string          `json:"amount"`
+	Moniker                 string          `json:"moniker"`
+	Identity                string          `json:"identity,omitempty"`
+	Website                 string          `json:"website,omitempty"`
+	SecurityContact         string          `json:"security,omitempty"`
+	Details                 string          `json:"details,omitempty"`
+	CommissionRate          string          `json:"commission-rate"`
+	CommissionMaxRate       string          `json:"commission-max-rate"`
+	CommissionMaxChangeRate string          `json:"commission-max-change-rate"`
+	MinSelfDelegation       string          `json:"min-self-delegation"`
+}
+
+func stakingCreateValidatorCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-validator [validator.json]",
+		Short: "create a validator operated by the signing key, with a self delegation",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(
+			`Create a validator from a JSON spec, with the signing key as operator and
+the amount delegated from it. The pubkey is the consensus key of the validator node,
+as printed by "<appd> tendermint show-validator".
+Example:
+$ lens tx staking create-validator validator.json --from mykey
+
+Where validator.json contains:
+{
+  "pubkey": {"@type": "/cosmos.crypto.ed25519.PubKey", "key": "oWg2ISpLF405Jcm2vXV+2v4fnjodh6aafuIdeoW+rUw="},
+  "amount": "1000000stake",
+  "moniker": "myvalidator",
+  "identity": "optional identity signature (ex. UPort or Keybase)",
+  "website": "validator's (optional) website",
+  "security": "validator's (optional) security contact email",
+  "details": "validator's (optional) details",
+  "commission-rate": "0.1",
+  "commission-max-rate": "0.2",
+  "commission-max-change-rate": "0.01",
+  "min-self-delegation": "1"
+}
+`,
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			key, _ := cmd.Flags().GetString(FlagFrom)
+			delAddr, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			msg, err := parseValidatorSpec(cl, bz)
+			if err != nil {
+				return fmt.Errorf("reading validator from %s: %w", args[0], err)
+			}
+			msg.DelegatorAddress = cl.MustEncodeAccAddr(delAddr)
+			msg.ValidatorAddress = cl.MustEncodeValAddr(sdk.ValAddress(delAddr))
+
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+
+	AddTxFlagsToCmd(cmd)
+	memoFlag(a.Viper, cmd)
+	return cmd
+}
+
+// parseValidatorSpec decodes a validator spec into a MsgCreateValidator without addresses.
+func parseValidatorSpec(cl *client.ChainClient, bz []byte) (*types.MsgCreateValidator, error) {
+	var spec validatorSpec
+	if err := json.Unmarshal(bz, &spec); err != nil {
+		return nil, err
+	}
+
+	if len(spec.PubKey) == 0 {
+		return nil, fmt.Errorf("pubkey is required")
+	}
+	var pk cryptotypes.PubKey
+	if err := cl.Codec.Marshaler.UnmarshalInterfaceJSON(spec.PubKey, &pk); err != nil {
+		return nil, fmt.Errorf("parsing pubkey: %w", err)
+	}
+	pkAny, err := codectypes.NewAnyWithValue(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := sdk.ParseCoinNormalized(spec.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("parsing amount: %w", err)
+	}
+
+	if spec.Moniker == "" {
+		return nil, fmt.Errorf("moniker is required")
+	}
+	description, err := types.NewDescription(spec.Moniker, spec.Identity, spec.Website, spec.SecurityContact, spec.Details).EnsureLength()
+	if err != nil {
+		return nil, err
+	}
+
+	var rates [3]sdk.Dec
+	for i, rate := range []struct{ name, value string }{
+		{"commission-rate", spec.CommissionRate},
+		{"commission-max-rate", spec.CommissionMaxRate},
+		{"commission-max-change-rate", spec.CommissionMaxChangeRate},
+	} {
+		if rates[i], err = sdk.NewDecFromStr(rate.value); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", rate.name, err)
+		}
+	}
+	commission := types.NewCommissionRates(rates[0], rates[1], rates[2])
+	if err := commission.Validate(); err != nil {
+		return nil, err
+	}
+
+	minSelfDelegation := sdk.OneInt()
+	if spec.MinSelfDelegation != "" {
+		var ok bool
+		if minSelfDelegation, ok = sdk.NewIntFromString(spec.MinSelfDelegation); !ok || !minSelfDelegation.IsPositive() {
+			return nil, fmt.Errorf("min-self-delegation must be a positive integer, got %s", spec.MinSelfDelegation)
+		}
+	}
+	if amount.Amount.LT(minSelfDelegation) {
+		return nil, fmt.Errorf("amount %s is lower than the min-self-delegation %s", amount, minSelfDelegation)
+	}
+
+	return &types.MsgCreateValidator{
+		Description:       description,
+		Commission:        commission,
+		MinSelfDelegation: minSelfDelegation,
+		Pubkey:            pkAny,
+		Value:             amount,
+	}, nil
+}
+
+func stakingEditValidatorCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit-validator",
+		Short: "edit the description and commission rate of the validator operated by the signing key",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(
+			`Edit the validator operated by the signing key. Only the fields given by flags
+are changed. The commission rate can be changed once a day, by at most the max
+change rate of the validator.
+Example:
+$ lens tx staking edit-validator --moniker newname --commission-rate 0.05 --from mykey
+`,
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			key, _ := cmd.Flags().GetString(FlagFrom)
+			operator, err := cl.AccountFromKeyOrAddress(key)
+			if err != nil {
+				return err
+			}
+
+			description := types.NewDescription(types.DoNotModifyDesc, types.DoNotModifyDesc, types.DoNotModifyDesc, types.DoNotModifyDesc, types.DoNotModifyDesc)
+			for flag, field := range map[string]*string{
+				flagMoniker:         &description.Moniker,
+				flagIdentity:        &description.Identity,
+				flagWebsite:         &description.Website,
+				flagSecurityContact: &description.SecurityContact,
+				flagDetails:         &description.Details,
+			} {
+				if cmd.Flags().Changed(flag) {
+					*field, _ = cmd.Flags().GetString(flag)
+				}
+			}
+
+			msg := &types.MsgEditValidator{
+				Description:      description,
+				ValidatorAddress: cl.MustEncodeValAddr(sdk.ValAddress(operator)),
+			}
+			if cmd.Flags().Changed(flagCommissionRate) {
+				rateStr, _ := cmd.Flags().GetString(flagCommissionRate)
+				rate, err := sdk.NewDecFromStr(rateStr)
+				if err != nil {
+					return fmt.Errorf("parsing commission rate: %w", err)
+				}
+				msg.CommissionRate = &rate
+			}
+			if cmd.Flags().Changed(flagMinSelfDelegation) {
+				minStr, _ := cmd.Flags().GetString(flagMinSelfDelegation)
+				minSelf, ok := sdk.NewIntFromString(minStr)
+				if !ok || !minSelf.IsPositive() {
+					return fmt.Errorf("min self delegation must be a positive integer, got %s", minStr)
+				}
+				msg.MinSelfDelegation = &minSelf
+			}
+			if msg.Description == (types.Description{
+				Moniker:         types.DoNotModifyDesc,
+				Identity:        types.DoNotModifyDesc,
+				Website:         types.DoNotModifyDesc,
+				SecurityContact: types.DoNotModifyDesc,
+				Details:         types.DoNotModifyDesc,
+			}) && msg.CommissionRate == nil && msg.MinSelfDelegation == nil {
+				return fmt.Errorf("nothing to edit, set at least one of the flags")
+			}
+
+			memo, err := cmd.Flags().GetString(flagMemo)
+			if err != nil {
+				return err
+			}
+			return sendMsgs(cmd, cl, []sdk.Msg{msg}, memo)
+		},
+	}
+
+	cmd.Flags().String(flagMoniker, "", "the validator's name")
+	cmd.Flags().String(flagIdentity, "", "the (optional) identity signature (ex. UPort or Keybase)")
+	cmd.Flags().String(flagWebsite, "", "the validator's (optional) website")
+	cmd.Flags().String(flagSecurityContact, "", "the validator's (optional) security contact email")
+	cmd.Flags().String(flagDetails, "", "the validator's (optional) details")
+	cmd.Flags().String(flagCommissionRate, "", "the new commission rate")
+	cmd.Flags().String(flagMinSelfDelegation, "", "the new minimum self delegation")
+	AddTxFlagsToCmd(cmd)
 	memoFlag(a.Viper, cmd)
 	return cmd
 }
@@ -201,8 +547,7 @@ $ lens query staking delegations cosmos1gghjut3ccd8ay0zduzj64hwre2fxs9ld75ru9p
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	flags.AddPaginationFlagsToCmd(cmd, "delegations")
-	return cmd
+	return paginationFlags(cmd, a.Viper)
 }
 
 func stakingDelegationCmd(a *appState) *cobra.Command {
@@ -297,8 +642,7 @@ $ lens query staking unbonding-delegations cosmos1gghjut3ccd8ay0zduzj64hwre2fxs9
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	flags.AddPaginationFlagsToCmd(cmd, "unbonding-delegations")
-	return cmd
+	return paginationFlags(cmd, a.Viper)
 }
 
 func stakingValidatorDelegationsCmd(a *appState) *cobra.Command {
@@ -327,8 +671,7 @@ $ lens query staking validator-delegations [validator address (valoper)]
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	flags.AddPaginationFlagsToCmd(cmd, "validator-delegations")
-	return cmd
+	return paginationFlags(cmd, a.Viper)
 }
 
 func stakingValidatorsCmd(a *appState) *cobra.Command {
@@ -368,8 +711,7 @@ $ lens query staking validators <bonded|unbonded|unbonding>
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	flags.AddPaginationFlagsToCmd(cmd, "validators")
-	return cmd
+	return paginationFlags(cmd, a.Viper)
 }
 
 func stakingValidatorCmd(a *appState) *cobra.Command {
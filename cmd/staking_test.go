@@ -0,0 +1,72 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testValoper = "cosmosvaloper1sjllsnramtg3ewxqwwrwjxfgc4n4ef9u2lcnj0"
+
+func TestTxStakingUnbond_GenerateOnly(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+
+	msgs := generateOnlyMsgs(t, sys, "tx", "staking", "unbond", testValoper, "100uatom")
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.staking.v1beta1.MsgUndelegate", msgs[0]["@type"])
+	require.Equal(t, testValoper, msgs[0]["validator_address"])
+
+	msgs = generateOnlyMsgs(t, sys, "tx", "staking", "cancel-unbond", testValoper, "100uatom", "1234")
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.staking.v1beta1.MsgCancelUnbondingDelegation", msgs[0]["@type"])
+	require.Equal(t, "1234", msgs[0]["creation_height"])
+
+	res := sys.Run(zaptest.NewLogger(t), "tx", "staking", "cancel-unbond", testValoper, "100uatom", "abc", "--generate-only", "--gas", "100000")
+	require.ErrorContains(t, res.Err, "not a positive integer")
+}
+
+func TestTxStakingCreateEditValidator_GenerateOnly(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+	sys.MustRun(t, "keys", "add")
+	res := sys.MustRun(t, "keys", "show")
+	operator := strings.TrimSpace(res.Stdout.String())
+
+	spec := filepath.Join(t.TempDir(), "validator.json")
+	require.NoError(t, os.WriteFile(spec, []byte(`{
+  "pubkey": {"@type": "/cosmos.crypto.ed25519.PubKey", "key": "oWg2ISpLF405Jcm2vXV+2v4fnjodh6aafuIdeoW+rUw="},
+  "amount": "1000000uatom",
+  "moniker": "myvalidator",
+  "commission-rate": "0.1",
+  "commission-max-rate": "0.2",
+  "commission-max-change-rate": "0.01",
+  "min-self-delegation": "1"
+}`), 0600))
+
+	msgs := generateOnlyMsgs(t, sys, "tx", "staking", "create-validator", spec)
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.staking.v1beta1.MsgCreateValidator", msgs[0]["@type"])
+	require.Equal(t, operator, msgs[0]["delegator_address"])
+	require.True(t, strings.HasPrefix(msgs[0]["validator_address"].(string), "cosmosvaloper1"))
+	require.Equal(t, "/cosmos.crypto.ed25519.PubKey", msgs[0]["pubkey"].(map[string]interface{})["@type"])
+	require.Equal(t, "myvalidator", msgs[0]["description"].(map[string]interface{})["moniker"])
+
+	msgs = generateOnlyMsgs(t, sys, "tx", "staking", "edit-validator", "--moniker", "newname", "--commission-rate", "0.05")
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.staking.v1beta1.MsgEditValidator", msgs[0]["@type"])
+	description := msgs[0]["description"].(map[string]interface{})
+	require.Equal(t, "newname", description["moniker"])
+	require.Equal(t, "[do-not-modify]", description["website"])
+	require.Equal(t, "0.050000000000000000", msgs[0]["commission_rate"])
+
+	res = sys.Run(zaptest.NewLogger(t), "tx", "staking", "edit-validator", "--generate-only", "--gas", "100000")
+	require.ErrorContains(t, res.Err, "nothing to edit")
+}
@@ -2,10 +2,12 @@ package cmd_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"testing"
 
 	"github.com/strangelove-ventures/lens/cmd"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 )
@@ -32,6 +34,21 @@ func NewSystem(t *testing.T) *System {
 	}
 }
 
+// generateOnlyMsgs runs the tx command of args with --generate-only, and returns the
+// messages of the unsigned tx it prints.
+func generateOnlyMsgs(t *testing.T, sys *System, args ...string) []map[string]interface{} {
+	t.Helper()
+	args = append(args, "--generate-only", "--gas", "100000")
+	res := sys.MustRun(t, args...)
+	var tx struct {
+		Body struct {
+			Messages []map[string]interface{} `json:"messages"`
+		} `json:"body"`
+	}
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &tx))
+	return tx.Body.Messages
+}
+
 // OverrideClients sets the client override mapping for the chain with the given name.
 // This override applies to all subsequent command invocations for this System.
 func (s *System) OverrideClients(name string, o cmd.ClientOverrides) {
@@ -16,13 +16,19 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 
+	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/strangelove-ventures/lens/client"
 	"github.com/strangelove-ventures/lens/client/query"
 	"go.uber.org/zap"
@@ -50,6 +56,7 @@ func tendermintCmd(a *appState) *cobra.Command {
 		numUnconfirmedTxs(a),
 		statusCmd(a),
 		queryTxCmd(a),
+		subscribeCmd(a),
 	)
 	return cmd
 }
@@ -329,33 +336,128 @@ func netInfoCmd(a *appState) *cobra.Command {
 }
 
 func numUnconfirmedTxs(a *appState) *cobra.Command {
-	// TODO: add example for parsing these txs
-	// _{*extraCredit*}_
 	cmd := &cobra.Command{
 		Use:     "mempool",
 		Aliases: []string{"unconfirmed", "mem"},
-		Short:   "query for number of unconfirmed txs",
-		Args:    cobra.NoArgs,
+		Short:   "query for number of unconfirmed txs, or list them decoded with --list",
+		Long: strings.TrimSpace(`Query the number of unconfirmed txs in the mempool of the node. With --list the txs are
+decoded with their sender, messages, fee and gas, and with --watch the mempool is polled and
+the txs entering and leaving it are printed as a JSON object per line. Only the first --limit
+txs of the mempool are watched, a tx pushed out of them by txs ahead of it is printed as removed
+though it is still in the mempool. Both can be filtered by the type URL of the messages of the
+txs or by their sender.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tendermint mempool
+$ %s tendermint mempool --list --msg-type /cosmos.bank.v1beta1.MsgSend
+$ %s tendermint mempool --watch --sender cosmos1...`, appName, appName, appName)),
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cl := a.Config.GetDefaultClient()
 			limit, err := cmd.Flags().GetInt("limit")
 			if err != nil {
 				return err
 			}
+			list, _ := cmd.Flags().GetBool(flagList)
+			watch, _ := cmd.Flags().GetBool(flagWatch)
+			msgTypes, _ := cmd.Flags().GetStringSlice(flagMsgType)
+			senders, _ := cmd.Flags().GetStringSlice(flagSender)
+			match := func(tx client.MempoolTx) bool {
+				return mempoolTxMatches(tx, msgTypes, senders)
+			}
+
+			switch {
+			case watch:
+				interval, _ := cmd.Flags().GetDuration(flagInterval)
+				if interval <= 0 {
+					return fmt.Errorf("--%s must be positive", flagInterval)
+				}
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				events, err := cl.WatchMempool(ctx, limit, interval)
+				if err != nil {
+					return err
+				}
+				for ev := range events {
+					if !match(ev.Tx) {
+						continue
+					}
+					event := "added"
+					if ev.Removed {
+						event = "removed"
+					}
+					line, err := json.Marshal(struct {
+						Event string `json:"event"`
+						client.MempoolTx
+					}{event, ev.Tx})
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(cmd.OutOrStdout(), string(line))
+				}
+				// The events only end when the command is interrupted
+				return nil
+			case list:
+				txs, err := cl.UnconfirmedTxs(cmd.Context(), limit)
+				if err != nil {
+					return err
+				}
+				matching := []client.MempoolTx{}
+				for _, tx := range txs {
+					if match(tx) {
+						matching = append(matching, tx)
+					}
+				}
+				return writeJSON(cmd.OutOrStdout(), matching)
+			case len(msgTypes) > 0 || len(senders) > 0:
+				return fmt.Errorf("--%s and --%s filter the txs of --%s or --%s", flagMsgType, flagSender, flagList, flagWatch)
+			}
+
 			block, err := cl.RPCClient.UnconfirmedTxs(cmd.Context(), &limit)
 			if err != nil {
 				return err
 			}
-			// for _, txbz := range block.Txs {
-			// 	fmt.Printf("%X\n", tmtypes.Tx(txbz).Hash())
-			// }
 			if err := writeJSON(cmd.OutOrStdout(), block); err != nil {
 				return err
 			}
 			return nil
 		},
 	}
-	return limitFlag(cmd, a.Viper)
+	return mempoolFlags(a.Viper, limitFlag(cmd, a.Viper))
+}
+
+func mempoolFlags(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Bool(flagList, false, "list the unconfirmed txs decoded")
+	cmd.Flags().Bool(flagWatch, false, "print the txs entering and leaving the first --limit txs of the mempool until interrupted, a tx pushed out of them is printed as removed though it is still in the mempool")
+	cmd.Flags().Duration(flagInterval, client.DefaultMempoolPollInterval, "how often to poll the mempool with --watch")
+	cmd.Flags().StringSlice(flagMsgType, nil, "comma separated type URLs, only show the txs with a message of one of them")
+	cmd.Flags().StringSlice(flagSender, nil, "comma separated addresses, only show the txs signed by one of them")
+	for _, flag := range []string{flagList, flagWatch, flagInterval, flagMsgType, flagSender} {
+		if err := v.BindPFlag(flag, cmd.Flags().Lookup(flag)); err != nil {
+			panic(err)
+		}
+	}
+	cmd.MarkFlagsMutuallyExclusive(flagList, flagWatch)
+	return cmd
+}
+
+// mempoolTxMatches returns whether tx has a message of one of msgTypes and a signer of
+// senders, an empty filter matches all txs.
+func mempoolTxMatches(tx client.MempoolTx, msgTypes, senders []string) bool {
+	return matchesAny(tx.MsgTypes, msgTypes) && matchesAny(tx.Signers, senders)
+}
+
+func matchesAny(values, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, v := range values {
+		for _, f := range filter {
+			if v == f {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func statusCmd(a *appState) *cobra.Command {
@@ -400,3 +502,76 @@ func queryTxCmd(a *appState) *cobra.Command {
 	}
 	return proveFlag(cmd, a.Viper)
 }
+
+func subscribeCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "subscribe [query]",
+		Aliases: []string{"sub"},
+		Short:   "stream the new blocks, txs or validator set updates of an event query as JSON lines",
+		Long: strings.TrimSpace(`Stream the events of a CometBFT event query as a JSON object per line, with the kind, height
+and data of each event. The query selects new blocks with tm.event='NewBlock', validator set
+updates with tm.event='ValidatorSetUpdates', or txs with tm.event='Tx' and conditions on their
+events, the default when there is no tm.event condition. The subscription reconnects when the
+connection is lost and resumes from the last height it printed.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s tendermint subscribe "tm.event='NewBlock'"
+$ %s tendermint subscribe "message.sender='cosmos1...'" --from-height 15000000`, appName, appName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			kind, query, err := client.ParseEventQuery(args[0])
+			if err != nil {
+				return err
+			}
+			fromHeight, err := cmd.Flags().GetInt64(flagFromHeight)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			events, err := cl.Subscribe(ctx, kind, query, client.SubscribeFromHeight(fromHeight))
+			if err != nil {
+				return err
+			}
+			for ev := range events {
+				line, err := marshalEvent(cl, ev)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(line))
+			}
+			// The events only end when the command is interrupted
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagFromHeight, 0, "first print the events from this height up to the latest block")
+	if err := a.Viper.BindPFlag(flagFromHeight, cmd.Flags().Lookup(flagFromHeight)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+// marshalEvent encodes an event of a subscription as a JSON line.
+func marshalEvent(cl *client.ChainClient, ev client.Event) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch ev.Kind {
+	case client.EventTx:
+		data, err = cl.MarshalProto(ev.Tx)
+	case client.EventValidatorSetUpdates:
+		data, err = cmtjson.Marshal(ev.ValidatorUpdates)
+	default:
+		data, err = cmtjson.Marshal(ev.Block)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Kind   client.EventKind `json:"kind"`
+		Height int64            `json:"height"`
+		Data   json.RawMessage  `json:"data"`
+	}{ev.Kind, ev.Height, data})
+}
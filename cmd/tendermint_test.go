@@ -5,8 +5,11 @@ import (
 	"testing"
 	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/strangelove-ventures/lens/client"
 	"github.com/strangelove-ventures/lens/cmd"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -15,6 +18,7 @@ import (
 	"github.com/cometbft/cometbft/rpc/client/mocks"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cometbft/cometbft/types"
+	"go.uber.org/zap/zaptest"
 )
 
 func TestTendermintBlock_SpecificHeight(t *testing.T) {
@@ -130,3 +134,64 @@ func TestTendermintStatus(t *testing.T) {
 
 	require.Empty(t, cmp.Diff(mockStatus, gotStatus, cmpopts.EquateEmpty()))
 }
+
+func TestTendermintSubscribe_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+
+	res := sys.Run(zaptest.NewLogger(t), "tendermint", "subscribe", "tm.event='NewRound'")
+	require.ErrorContains(t, res.Err, "unsupported event kind")
+
+	res = sys.Run(zaptest.NewLogger(t), "tendermint", "subscribe", "tm.event='NewBlock' AND tx.height > 5")
+	require.ErrorContains(t, res.Err, "can't be filtered")
+}
+
+func TestTendermintMempoolList(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+
+	cdc := client.MakeCodec(cmd.ModuleBasics, nil)
+	sendTx := func(from sdk.AccAddress, fee int64) types.Tx {
+		builder := cdc.TxConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(bankTypes.NewMsgSend(from, sdk.AccAddress("to__________________"), sdk.NewCoins(sdk.NewInt64Coin("uatom", 5)))))
+		builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("uatom", fee)))
+		builder.SetGasLimit(90000)
+		bz, err := cdc.TxConfig.TxEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return bz
+	}
+	alice, bob := sdk.AccAddress("alice_______________"), sdk.AccAddress("bob_________________")
+	mc := new(mocks.Client)
+	limit := 100
+	mc.On("UnconfirmedTxs", mock.Anything, &limit).Return(&coretypes.ResultUnconfirmedTxs{
+		Count: 3,
+		Txs:   []types.Tx{sendTx(alice, 1000), sendTx(bob, 2000), types.Tx("not a tx")},
+	}, nil)
+	sys.OverrideClients("cosmoshub", cmd.ClientOverrides{
+		RPCClient: mc,
+	})
+
+	res := sys.MustRun(t, "tendermint", "mempool", "--list")
+	var txs []client.MempoolTx
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &txs))
+	require.Len(t, txs, 3)
+	require.Equal(t, []string{"/cosmos.bank.v1beta1.MsgSend"}, txs[0].MsgTypes)
+	require.Equal(t, "1000uatom", txs[0].Fee.String())
+	require.Equal(t, uint64(90000), txs[0].Gas)
+	require.NotEmpty(t, txs[2].Error)
+
+	bobAddr := sdk.MustBech32ifyAddressBytes("cosmos", bob)
+	res = sys.MustRun(t, "tendermint", "mempool", "--list", "--sender", bobAddr, "--msg-type", "/cosmos.bank.v1beta1.MsgSend")
+	txs = nil
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &txs))
+	require.Len(t, txs, 1)
+	require.Equal(t, bobAddr, txs[0].Sender)
+
+	res = sys.Run(zaptest.NewLogger(t), "tendermint", "mempool", "--sender", bobAddr)
+	require.ErrorContains(t, res.Err, "filter the txs of --list or --watch")
+
+	res = sys.Run(zaptest.NewLogger(t), "tendermint", "mempool", "--watch", "--interval", "0s")
+	require.ErrorContains(t, res.Err, "--interval must be positive")
+}
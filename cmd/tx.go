@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/lens/client"
 )
 
 // TxCommand registers a new tx command.
@@ -16,13 +20,81 @@ func txCmd(a *appState) *cobra.Command {
 		authzTxCmd(a),
 		bankTxCmd(a),
 		distributionTxCmd(a),
-		feegrantTxCmd(),
-		govTxCmd(),
+		feegrantTxCmd(a),
+		govTxCmd(a),
+		ibcTxCmd(a),
 		stakingTxCmd(a),
-		slashingTxCmd(),
+		slashingTxCmd(a),
+		txSignCmd(a),
+		txMultisignCmd(a),
+		txBroadcastCmd(a),
+		txDecodeCmd(a),
+		txEncodeCmd(a),
 	)
 
-	return cmd
+	return txBuildFlags(a.Viper, broadcastModeFlag(a.Viper, cmd))
+}
+
+// sendMsgs signs and broadcasts msgs and prints the response. With --generate-only
+// the unsigned tx is printed instead, to be signed with `lens tx sign`.
+func sendMsgs(cmd *cobra.Command, cl *client.ChainClient, msgs []sdk.Msg, memo string) error {
+	opts, err := txOptionsFromFlags(cmd, cl)
+	if err != nil {
+		return err
+	}
+
+	if generateOnly, _ := cmd.Flags().GetBool(flagGenerateOnly); generateOnly {
+		txb, err := cl.BuildUnsignedTx(cmd.Context(), msgs, memo, opts...)
+		if err != nil {
+			return err
+		}
+		bz, err := cl.TxJSON(txb.GetTx())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+		return nil
+	}
+
+	return cl.HandleAndPrintMsgSend(cl.SendMsgs(cmd.Context(), msgs, memo, opts...))
+}
+
+// txOptionsFromFlags returns the tx options set by the flags of the tx command.
+// The flags are only defined below the tx command, elsewhere no options are returned.
+func txOptionsFromFlags(cmd *cobra.Command, cl *client.ChainClient) ([]client.TxOption, error) {
+	var opts []client.TxOption
+	if gas, _ := cmd.Flags().GetUint64(flagGas); gas != 0 {
+		opts = append(opts, client.WithGas(gas))
+	}
+	if granter, _ := cmd.Flags().GetString(flagFeeGranter); granter != "" {
+		addr, err := addressFromKeyOrAddress(cl, granter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee granter: %w", err)
+		}
+		opts = append(opts, client.WithFeeGranter(addr))
+	}
+	if payer, _ := cmd.Flags().GetString(flagFeePayer); payer != "" {
+		addr, err := addressFromKeyOrAddress(cl, payer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee payer: %w", err)
+		}
+		opts = append(opts, client.WithFeePayer(addr))
+	}
+	return opts, nil
+}
+
+// addressFromKeyOrAddress returns the address of the key named keyOrAddress, or
+// decodes it as an address. Unlike AccountFromKeyOrAddress, it leaves the key
+// used for signing unchanged.
+func addressFromKeyOrAddress(cl *client.ChainClient, keyOrAddress string) (sdk.AccAddress, error) {
+	if cl.KeyExists(keyOrAddress) {
+		info, err := cl.Keybase.Key(keyOrAddress)
+		if err != nil {
+			return nil, err
+		}
+		return info.GetAddress()
+	}
+	return cl.DecodeBech32AccAddr(keyOrAddress)
 }
 
 // authCmd returns the transaction commands for this module
@@ -87,7 +159,7 @@ func distributionTxCmd(a *appState) *cobra.Command {
 }
 
 // feegrantTxCmd returns the fee grant tx commands for this module
-func feegrantTxCmd() *cobra.Command {
+func feegrantTxCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "feegrant",
 		Aliases: []string{"f", "fee"},
@@ -95,8 +167,22 @@ func feegrantTxCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
-	// feegrantFeeGrantCmd(),
-	// feegrantRevoteFeeGrantCmd(),
+		feegrantGrantCmd(a),
+		feegrantRevokeCmd(a),
+	)
+
+	return cmd
+}
+
+// ibcTxCmd returns the ibc tx commands
+func ibcTxCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ibc",
+		Short: "ibc transaction commands",
+	}
+
+	cmd.AddCommand(
+		ibcTransferCmd(a),
 	)
 
 	return cmd
@@ -113,16 +199,17 @@ func stakingTxCmd(a *appState) *cobra.Command {
 	cmd.AddCommand(
 		stakingDelegateCmd(a),
 		stakingRedelegateCmd(a),
-		// stakingCreateValidatorCmd(),
-		// stakingEditValidatorCmd(),
-		// stakingUnbondCmd(),
+		stakingUnbondCmd(a),
+		stakingCancelUnbondCmd(a),
+		stakingCreateValidatorCmd(a),
+		stakingEditValidatorCmd(a),
 	)
 
 	return cmd
 }
 
 // govTxCmd returns the gov tx commands for this module
-func govTxCmd() *cobra.Command {
+func govTxCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "governance",
 		Aliases: []string{"gov", "g"},
@@ -130,17 +217,17 @@ func govTxCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
-	// govSubmitProposalCmd(),
-	// govDepositCmd(),
-	// govVoteCmd(),
-	// govWeightedVoteCmd(),
+		govSubmitProposalCmd(a),
+		govDepositCmd(a),
+		govVoteCmd(a),
+		govWeightedVoteCmd(a),
 	)
 
 	return cmd
 }
 
 // slashingTxCmd returns the slashing tx commands for this module
-func slashingTxCmd() *cobra.Command {
+func slashingTxCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "slashing",
 		Aliases: []string{"sl", "slash"},
@@ -148,7 +235,7 @@ func slashingTxCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
-	// slashingUnjailCmd(),
+		slashingUnjailCmd(a),
 	)
 
 	return cmd
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/strangelove-ventures/lens/client"
+)
+
+const (
+	flagEvents  = "events"
+	flagOrderBy = "order-by"
+	flagPage    = "page"
+	flagPerPage = "per-page"
+)
+
+// eventCondition matches a condition of --events, an attribute of an event type compared to a value.
+var eventCondition = regexp.MustCompile(`^([\w-]+\.[\w-]+)\s*(>=|<=|=|>|<)\s*(.+)$`)
+
+// txsQueryCmd returns the command to search txs by their events
+func txsQueryCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "txs",
+		Args:  cobra.NoArgs,
+		Short: "search the txs matching all the given events, decoded with their messages",
+		Long: strings.TrimSpace(`Search the txs matching all the conditions of --events, separated by '&', each the
+value of an event attribute like message.sender=cosmos1... or a comparison like tx.height>=100.
+A '&' in a value is escaped as '\&'. The txs are printed with their decoded messages and results,
+a page at a time or all of them with --all, and the txs that can't be decoded are listed apart
+with their raw results.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s query txs --events 'message.sender=cosmos1...&transfer.recipient=cosmos1...'
+$ %s query txs --events 'message.action=/cosmos.gov.v1beta1.MsgVote' --order-by desc --per-page 10
+$ %s query txs --events 'transfer.recipient=cosmos1...' --all`, appName, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl := a.Config.GetDefaultClient()
+			eventsArg, _ := cmd.Flags().GetString(flagEvents)
+			events, err := parseEventConditions(eventsArg)
+			if err != nil {
+				return err
+			}
+			orderBy, _ := cmd.Flags().GetString(flagOrderBy)
+			if orderBy != "asc" && orderBy != "desc" {
+				return fmt.Errorf("invalid --%s %q, expected asc or desc", flagOrderBy, orderBy)
+			}
+
+			if all, _ := cmd.Flags().GetBool(flagAll); all {
+				res, err := cl.SearchAllTxs(cmd.Context(), events, orderBy)
+				if err != nil {
+					return err
+				}
+				return printTxSearchResult(cl, res)
+			}
+			page, _ := cmd.Flags().GetInt(flagPage)
+			perPage, _ := cmd.Flags().GetInt(flagPerPage)
+			res, err := cl.SearchTxs(cmd.Context(), events, page, perPage, orderBy)
+			if err != nil {
+				return err
+			}
+			return printTxSearchResult(cl, res)
+		},
+	}
+	return txsSearchFlags(a.Viper, cmd)
+}
+
+// printTxSearchResult prints the txs of a search, with the raw results of the txs that
+// couldn't be decoded under "undecodable" when there are some.
+func printTxSearchResult(cl *client.ChainClient, res *client.TxSearchResult) error {
+	if len(res.Undecodable) == 0 {
+		return cl.PrintObject(res.SearchTxsResult)
+	}
+	bz, err := cl.MarshalProto(res.SearchTxsResult)
+	if err != nil {
+		return err
+	}
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &out); err != nil {
+		return err
+	}
+	if out["undecodable"], err = cmtjson.Marshal(res.Undecodable); err != nil {
+		return err
+	}
+	return cl.PrintObject(out)
+}
+
+func txsSearchFlags(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(flagEvents, "", "conditions on the events of the txs separated by '&', like message.sender=cosmos1..., a '&' in a value is escaped as '\\&'")
+	cmd.Flags().String(flagOrderBy, "asc", "order of the txs by height, asc or desc")
+	cmd.Flags().Int(flagPage, 1, "page of the txs to query")
+	cmd.Flags().Int(flagPerPage, 30, "number of txs per page, at most 100")
+	cmd.Flags().Bool(flagAll, false, "query all the pages of txs")
+	for _, flag := range []string{flagEvents, flagOrderBy, flagPage, flagPerPage, flagAll} {
+		if err := v.BindPFlag(flag, cmd.Flags().Lookup(flag)); err != nil {
+			panic(err)
+		}
+	}
+	if err := cmd.MarkFlagRequired(flagEvents); err != nil {
+		panic(err)
+	}
+	cmd.MarkFlagsMutuallyExclusive(flagAll, flagPage)
+	cmd.MarkFlagsMutuallyExclusive(flagAll, flagPerPage)
+	return cmd
+}
+
+// parseEventConditions returns the conditions of a tx search from conditions like
+// "message.sender=cosmos1...&tx.height>=100". Values compared for equality are quoted,
+// except the heights of txs, other comparisons are numeric. A '&' in a value is escaped as
+// '\&', values can't hold a single quote since event queries have no way to escape it.
+func parseEventConditions(s string) ([]string, error) {
+	var events []string
+	for _, cond := range splitEventConditions(s) {
+		m := eventCondition.FindStringSubmatch(strings.TrimSpace(cond))
+		if m == nil {
+			return nil, fmt.Errorf("invalid event condition %q, expected {eventType}.{attribute}={value}", cond)
+		}
+		key, op, value := m[1], m[2], strings.Trim(m[3], `'"`)
+		if strings.Contains(value, "'") {
+			return nil, fmt.Errorf("invalid event condition %q, values can't contain a single quote", cond)
+		}
+		if op == "=" && key != "tx.height" {
+			value = "'" + value + "'"
+		}
+		events = append(events, key+op+value)
+	}
+	if _, err := cmtquery.New(strings.Join(events, " AND ")); err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", flagEvents, err)
+	}
+	return events, nil
+}
+
+// splitEventConditions splits conditions on the '&' separating them, unescaping the '\&'
+// of their values.
+func splitEventConditions(s string) []string {
+	var (
+		conds []string
+		cond  strings.Builder
+	)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], `\&`):
+			cond.WriteByte('&')
+			i++
+		case s[i] == '&':
+			conds = append(conds, cond.String())
+			cond.Reset()
+		default:
+			cond.WriteByte(s[i])
+		}
+	}
+	return append(conds, cond.String())
+}
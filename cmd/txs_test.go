@@ -0,0 +1,124 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/lens/client"
+	"github.com/strangelove-ventures/lens/cmd"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestQueryTxs(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+
+	cdc := client.MakeCodec(cmd.ModuleBasics, nil)
+	builder := cdc.TxConfig.NewTxBuilder()
+	from, to := sdk.AccAddress("from________________"), sdk.AccAddress("to__________________")
+	require.NoError(t, builder.SetMsgs(bankTypes.NewMsgSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("uatom", 5)))))
+	builder.SetMemo("rent")
+	txBz, err := cdc.TxConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	mc := new(mocks.Client)
+	page, perPage := 2, 10
+	mc.On("TxSearch", mock.Anything, "message.sender='cosmos1abc' AND tx.height>=5", false, &page, &perPage, "desc").Return(&coretypes.ResultTxSearch{
+		Txs:        []*coretypes.ResultTx{{Hash: types.Tx(txBz).Hash(), Height: 12, Tx: txBz}},
+		TotalCount: 11,
+	}, nil)
+	height := int64(12)
+	mc.On("Header", mock.Anything, &height).Return(&coretypes.ResultHeader{Header: &types.Header{Height: 12, Time: time.Now()}}, nil)
+	sys.OverrideClients("cosmoshub", cmd.ClientOverrides{
+		RPCClient: mc,
+	})
+
+	res := sys.MustRun(t, "query", "txs", "--events", "message.sender=cosmos1abc&tx.height>=5", "--order-by", "desc", "--page", "2", "--per-page", "10")
+	require.Empty(t, res.Stderr.String())
+
+	var got struct {
+		TotalCount string `json:"total_count"`
+		PageNumber string `json:"page_number"`
+		Txs        []struct {
+			Height string `json:"height"`
+			Tx     struct {
+				Body struct {
+					Messages []map[string]interface{} `json:"messages"`
+					Memo     string                   `json:"memo"`
+				} `json:"body"`
+			} `json:"tx"`
+		} `json:"txs"`
+	}
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &got))
+	require.Equal(t, "11", got.TotalCount)
+	require.Equal(t, "2", got.PageNumber)
+	require.Len(t, got.Txs, 1)
+	require.Equal(t, "12", got.Txs[0].Height)
+	require.Equal(t, "rent", got.Txs[0].Tx.Body.Memo)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", got.Txs[0].Tx.Body.Messages[0]["@type"])
+}
+
+func TestQueryTxs_InvalidEvents(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+
+	res := sys.Run(zaptest.NewLogger(t), "query", "txs", "--events", "message.sender")
+	require.ErrorContains(t, res.Err, "invalid event condition")
+
+	res = sys.Run(zaptest.NewLogger(t), "query", "txs", "--events", "tx.memo=it's")
+	require.ErrorContains(t, res.Err, "can't contain a single quote")
+
+	res = sys.Run(zaptest.NewLogger(t), "query", "txs", "--events", "message.sender=cosmos1abc", "--order-by", "newest")
+	require.ErrorContains(t, res.Err, "expected asc or desc")
+
+	res = sys.Run(zaptest.NewLogger(t), "query", "txs", "--events", "message.sender=cosmos1abc", "--all", "--page", "2")
+	require.ErrorContains(t, res.Err, "none of the others can be")
+}
+
+func TestQueryTxs_Undecodable(t *testing.T) {
+	t.Parallel()
+
+	sys := NewSystem(t)
+
+	mc := new(mocks.Client)
+	page, perPage := 1, 30
+	garbage := types.Tx("not a tx")
+	mc.On("TxSearch", mock.Anything, "tx.memo='a&b'", false, &page, &perPage, "asc").Return(&coretypes.ResultTxSearch{
+		Txs:        []*coretypes.ResultTx{{Hash: garbage.Hash(), Height: 12, Tx: garbage}},
+		TotalCount: 1,
+	}, nil)
+	height := int64(12)
+	mc.On("Header", mock.Anything, &height).Return(&coretypes.ResultHeader{Header: &types.Header{Height: 12, Time: time.Now()}}, nil)
+	sys.OverrideClients("cosmoshub", cmd.ClientOverrides{
+		RPCClient: mc,
+	})
+
+	res := sys.MustRun(t, "query", "txs", "--events", `tx.memo=a\&b`)
+
+	var got struct {
+		Count       string `json:"count"`
+		Txs         []json.RawMessage
+		Undecodable []struct {
+			Result struct {
+				Height string `json:"height"`
+			} `json:"result"`
+			Error string `json:"error"`
+		} `json:"undecodable"`
+	}
+	require.NoError(t, json.Unmarshal(res.Stdout.Bytes(), &got))
+	require.Equal(t, "0", got.Count)
+	require.Empty(t, got.Txs)
+	require.Len(t, got.Undecodable, 1)
+	require.Equal(t, "12", got.Undecodable[0].Result.Height)
+	require.NotEmpty(t, got.Undecodable[0].Error)
+}